@@ -0,0 +1,84 @@
+// Command import memasukkan film dari file JSON / CSV berbentuk data TMDB ke database.
+//
+//	go run ./cmd/import -file movies.json
+//	go run ./cmd/import -file movies.csv -report report.json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/config"
+	"github.com/Darari17/be-go-tickitz-app/internal/imports"
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	path := flag.String("file", "", "file JSON / CSV yang akan di-import")
+	format := flag.String("format", "", "json atau csv (default: dari ekstensi file)")
+	reportPath := flag.String("report", "", "simpan laporan per baris ke file JSON ini")
+	flag.Parse()
+
+	if *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	importFormat := imports.Format(*format)
+	if importFormat == "" {
+		importFormat, _ = imports.FormatFromFilename(*path)
+	}
+	if importFormat != imports.FormatJSON && importFormat != imports.FormatCSV {
+		log.Fatalln("Unknown import format, use -format json or -format csv")
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("Failed to load env\nCause: ", err.Error())
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		log.Fatalln("Failed to open file\nCause: ", err.Error())
+	}
+	records, err := imports.Parse(file, importFormat)
+	file.Close()
+	if err != nil {
+		log.Fatalln("Failed to parse file\nCause: ", err.Error())
+	}
+
+	db, err := config.InitDB()
+	if err != nil {
+		log.Fatalln("Failed to connect to database\nCause: ", err.Error())
+	}
+	defer db.Close()
+	if err := config.TestDB(db); err != nil {
+		log.Fatalln("Ping to DB failed\nCause: ", err.Error())
+	}
+
+	report := imports.Run(context.Background(), repositories.NewMovieRepo(db), records)
+	for _, row := range report.Rows {
+		if row.Status == models.ImportFailed {
+			log.Printf("row %d (tmdb_id %d): %s", row.Row, row.TMDBID, row.Error)
+		}
+	}
+	log.Printf("Import finished: %d created, %d updated, %d failed", report.Created, report.Updated, report.Failed)
+
+	if *reportPath != "" {
+		raw, err := json.MarshalIndent(report, "", "  ")
+		if err == nil {
+			err = os.WriteFile(*reportPath, raw, 0o644)
+		}
+		if err != nil {
+			log.Println("Failed to write report\nCause: ", err.Error())
+		}
+	}
+
+	if report.Failed > 0 {
+		db.Close()
+		os.Exit(1)
+	}
+}
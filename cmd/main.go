@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"time"
 
 	"github.com/Darari17/be-go-tickitz-app/internal/config"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
 	"github.com/Darari17/be-go-tickitz-app/internal/routers"
+	"github.com/Darari17/be-go-tickitz-app/internal/workers"
 	"github.com/joho/godotenv"
 )
 
@@ -37,6 +41,8 @@ func main() {
 	}
 	log.Println("DB Connected")
 
+	go workers.ExpireOrders(context.Background(), repositories.NewOrderRepo(db), time.Minute)
+
 	router := routers.InitRouter(db)
 	router.Run("localhost:8080")
 }
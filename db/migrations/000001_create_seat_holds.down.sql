@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS seat_holds_seats;
+DROP TABLE IF EXISTS seat_holds;
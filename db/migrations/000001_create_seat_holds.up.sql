@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS seat_holds (
+    id           SERIAL PRIMARY KEY,
+    users_id     INT       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    schedules_id INT       NOT NULL REFERENCES schedules (id) ON DELETE CASCADE,
+    expires_at   TIMESTAMP NOT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_seat_holds_schedule_expiry ON seat_holds (schedules_id, expires_at);
+
+CREATE TABLE IF NOT EXISTS seat_holds_seats (
+    seat_holds_id INT NOT NULL REFERENCES seat_holds (id) ON DELETE CASCADE,
+    seats_id      INT NOT NULL REFERENCES seats (id) ON DELETE CASCADE,
+    PRIMARY KEY (seat_holds_id, seats_id)
+);
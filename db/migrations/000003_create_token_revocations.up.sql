@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti        TEXT PRIMARY KEY,
+    users_id   INT         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expiry ON revoked_tokens (expires_at);
+
+-- semua access token user yang terbit sebelum revoked_at dianggap tidak berlaku
+CREATE TABLE IF NOT EXISTS user_session_revocations (
+    users_id   INT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    revoked_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS uq_seats_cinema_code;
+
+ALTER TABLE seats
+    DROP COLUMN IF EXISTS is_blocked,
+    DROP COLUMN IF EXISTS category,
+    DROP COLUMN IF EXISTS column_number,
+    DROP COLUMN IF EXISTS row_code,
+    DROP COLUMN IF EXISTS cinemas_id;
@@ -0,0 +1,15 @@
+-- kursi dengan cinemas_id NULL adalah kursi global lama, dipakai untuk cinema yang belum punya layout
+ALTER TABLE seats
+    ADD COLUMN IF NOT EXISTS cinemas_id    INT     NULL REFERENCES cinemas (id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS row_code      TEXT    NULL,
+    ADD COLUMN IF NOT EXISTS column_number INT     NULL,
+    ADD COLUMN IF NOT EXISTS category      TEXT    NOT NULL DEFAULT 'regular',
+    ADD COLUMN IF NOT EXISTS is_blocked    BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_seats_cinema_code ON seats (cinemas_id, seat_code) WHERE cinemas_id IS NOT NULL;
+
+-- isi posisi kursi global dari seat_code, contoh "A1" -> row A column 1
+UPDATE seats
+SET row_code      = UPPER(substring(seat_code FROM '^[A-Za-z]+')),
+    column_number = substring(seat_code FROM '[0-9]+$')::INT
+WHERE row_code IS NULL;
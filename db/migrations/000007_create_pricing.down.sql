@@ -0,0 +1,12 @@
+ALTER TABLE orders_seats
+    DROP COLUMN IF EXISTS price,
+    DROP COLUMN IF EXISTS rule_adjustment,
+    DROP COLUMN IF EXISTS category_surcharge,
+    DROP COLUMN IF EXISTS base_price;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS total_price;
+
+DROP TABLE IF EXISTS pricing_rules;
+DROP TABLE IF EXISTS seat_category_prices;
+
+ALTER TABLE cinemas DROP COLUMN IF EXISTS base_price;
@@ -0,0 +1,36 @@
+-- harga dasar cinema dipakai kalau schedule tidak punya harga sendiri (price = 0)
+ALTER TABLE cinemas ADD COLUMN IF NOT EXISTS base_price INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS seat_category_prices (
+    category  TEXT PRIMARY KEY,
+    surcharge INT  NOT NULL DEFAULT 0
+);
+
+INSERT INTO seat_category_prices (category, surcharge)
+VALUES ('regular', 0), ('vip', 15000), ('love_nest', 25000)
+ON CONFLICT (category) DO NOTHING;
+
+-- aturan penyesuaian harga per kursi berdasarkan hari (weekday/weekend) dan jam tayang.
+-- time_from > time_to berarti rentang melewati tengah malam.
+CREATE TABLE IF NOT EXISTS pricing_rules (
+    id         SERIAL PRIMARY KEY,
+    name       TEXT      NOT NULL,
+    day_type   TEXT      NULL CHECK (day_type IN ('weekday', 'weekend')),
+    time_from  TIME      NULL,
+    time_to    TIME      NULL,
+    adjustment INT       NOT NULL,
+    is_active  BOOLEAN   NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO pricing_rules (name, day_type, time_from, time_to, adjustment)
+VALUES ('Weekend', 'weekend', NULL, NULL, 10000),
+       ('Matinee', NULL, NULL, '12:00', -5000);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS total_price INT NOT NULL DEFAULT 0;
+
+ALTER TABLE orders_seats
+    ADD COLUMN IF NOT EXISTS base_price         INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS category_surcharge INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS rule_adjustment    INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS price              INT NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- total_price = harga semua kursi + service_fee
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS service_fee INT NOT NULL DEFAULT 0;
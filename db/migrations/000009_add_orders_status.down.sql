@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_orders_status_expiry;
+
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS refunded_at,
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS expired_at,
+    DROP COLUMN IF EXISTS used_at,
+    DROP COLUMN IF EXISTS paid_at,
+    DROP COLUMN IF EXISTS expires_at,
+    DROP COLUMN IF EXISTS status;
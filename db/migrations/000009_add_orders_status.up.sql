@@ -0,0 +1,16 @@
+-- order lama sudah final, jadi dianggap paid
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS status       TEXT      NOT NULL DEFAULT 'paid'
+        CHECK (status IN ('pending', 'paid', 'used', 'expired', 'cancelled', 'refunded')),
+    ADD COLUMN IF NOT EXISTS expires_at   TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS paid_at      TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS used_at      TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS expired_at   TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS refunded_at  TIMESTAMP NULL;
+
+UPDATE orders SET paid_at = created_at WHERE status = 'paid' AND paid_at IS NULL;
+
+ALTER TABLE orders ALTER COLUMN status SET DEFAULT 'pending';
+
+CREATE INDEX IF NOT EXISTS idx_orders_status_expiry ON orders (status, expires_at);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS payment_intents (
+    id           SERIAL PRIMARY KEY,
+    orders_id    INT       NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    provider     TEXT      NOT NULL,
+    charge_id    TEXT      NOT NULL UNIQUE,
+    amount       INT       NOT NULL,
+    checkout_url TEXT      NOT NULL DEFAULT '',
+    status       TEXT      NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'succeeded', 'failed', 'refunded')),
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_intents_order ON payment_intents (orders_id);
@@ -0,0 +1,4 @@
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS logo_path,
+    DROP COLUMN IF EXISTS display_order,
+    DROP COLUMN IF EXISTS is_active;
@@ -0,0 +1,4 @@
+ALTER TABLE payments
+    ADD COLUMN IF NOT EXISTS is_active     BOOLEAN NOT NULL DEFAULT TRUE,
+    ADD COLUMN IF NOT EXISTS display_order INT     NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS logo_path     TEXT    NULL;
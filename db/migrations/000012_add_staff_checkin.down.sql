@@ -0,0 +1,6 @@
+-- nilai enum 'staff' tidak bisa dihapus dari tipe enum, user staff dikembalikan menjadi user biasa
+UPDATE users SET role = 'user' WHERE role::text = 'staff';
+
+ALTER TABLE orders_seats
+    DROP COLUMN IF EXISTS admitted_by,
+    DROP COLUMN IF EXISTS admitted_at;
@@ -0,0 +1,17 @@
+-- kalau kolom users.role memakai tipe enum, tambahkan nilai 'staff'
+DO $$
+DECLARE
+    role_type regtype;
+BEGIN
+    SELECT a.atttypid::regtype INTO role_type
+    FROM pg_attribute a
+    WHERE a.attrelid = 'users'::regclass AND a.attname = 'role';
+
+    IF EXISTS (SELECT 1 FROM pg_type WHERE oid = role_type AND typtype = 'e') THEN
+        EXECUTE format('ALTER TYPE %s ADD VALUE IF NOT EXISTS %L', role_type, 'staff');
+    END IF;
+END $$;
+
+ALTER TABLE orders_seats
+    ADD COLUMN IF NOT EXISTS admitted_at TIMESTAMP NULL,
+    ADD COLUMN IF NOT EXISTS admitted_by INT       NULL REFERENCES users (id) ON DELETE SET NULL;
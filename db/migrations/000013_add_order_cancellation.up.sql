@@ -0,0 +1,22 @@
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS cancel_reason TEXT NULL,
+    ADD COLUMN IF NOT EXISTS cancelled_by  INT  NULL REFERENCES users (id) ON DELETE SET NULL;
+
+-- payment_intents_id NULL untuk order lama yang dibayar sebelum ada payment gateway,
+-- refund seperti itu tetap pending dan diproses manual
+CREATE TABLE IF NOT EXISTS refunds (
+    id                 SERIAL PRIMARY KEY,
+    orders_id          INT       NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    payment_intents_id INT       NULL REFERENCES payment_intents (id) ON DELETE SET NULL,
+    amount             INT       NOT NULL CHECK (amount > 0),
+    percent            INT       NOT NULL CHECK (percent BETWEEN 1 AND 100),
+    reason             TEXT      NOT NULL DEFAULT '',
+    is_forced          BOOLEAN   NOT NULL DEFAULT FALSE,
+    status             TEXT      NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'succeeded', 'failed')),
+    requested_by       INT       NULL REFERENCES users (id) ON DELETE SET NULL,
+    created_at         TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMP NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_order ON refunds (orders_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_movies_tmdb_id;
+
+ALTER TABLE movies DROP COLUMN IF EXISTS tmdb_id;
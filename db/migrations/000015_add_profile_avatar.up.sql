@@ -0,0 +1 @@
+ALTER TABLE profile ADD COLUMN IF NOT EXISTS avatar TEXT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_casts_name_lower;
+DROP INDEX IF EXISTS idx_genres_name_lower;
@@ -0,0 +1,41 @@
+-- nama kembar (beda huruf besar kecil) digabung ke id terkecil sebelum unique index dibuat
+INSERT INTO movies_genres (movies_id, genres_id)
+SELECT DISTINCT mg.movies_id, d.keep_id
+FROM movies_genres mg
+JOIN (SELECT id, MIN(id) OVER (PARTITION BY LOWER(name)) AS keep_id FROM genres) d ON d.id = mg.genres_id
+WHERE d.id <> d.keep_id
+AND NOT EXISTS (SELECT 1 FROM movies_genres x WHERE x.movies_id = mg.movies_id AND x.genres_id = d.keep_id);
+
+DELETE FROM movies_genres
+WHERE genres_id IN (
+    SELECT id FROM (SELECT id, MIN(id) OVER (PARTITION BY LOWER(name)) AS keep_id FROM genres) d
+    WHERE d.id <> d.keep_id
+);
+
+DELETE FROM genres
+WHERE id IN (
+    SELECT id FROM (SELECT id, MIN(id) OVER (PARTITION BY LOWER(name)) AS keep_id FROM genres) d
+    WHERE d.id <> d.keep_id
+);
+
+INSERT INTO movies_casts (movies_id, casts_id)
+SELECT DISTINCT mc.movies_id, d.keep_id
+FROM movies_casts mc
+JOIN (SELECT id, MIN(id) OVER (PARTITION BY LOWER(name)) AS keep_id FROM casts) d ON d.id = mc.casts_id
+WHERE d.id <> d.keep_id
+AND NOT EXISTS (SELECT 1 FROM movies_casts x WHERE x.movies_id = mc.movies_id AND x.casts_id = d.keep_id);
+
+DELETE FROM movies_casts
+WHERE casts_id IN (
+    SELECT id FROM (SELECT id, MIN(id) OVER (PARTITION BY LOWER(name)) AS keep_id FROM casts) d
+    WHERE d.id <> d.keep_id
+);
+
+DELETE FROM casts
+WHERE id IN (
+    SELECT id FROM (SELECT id, MIN(id) OVER (PARTITION BY LOWER(name)) AS keep_id FROM casts) d
+    WHERE d.id <> d.keep_id
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_genres_name_lower ON genres (LOWER(name));
+CREATE UNIQUE INDEX IF NOT EXISTS idx_casts_name_lower ON casts (LOWER(name));
@@ -15,32 +15,90 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/movies": {
+        "/admin/analytics/sales": {
             "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Semua data Movie untuk admin",
+                "description": "Revenue, tiket terjual dan okupansi per film / schedule / cinema / lokasi untuk schedule yang tayang di rentang tanggal.\nHanya order paid dan used yang dihitung, revenue tidak termasuk service fee.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin-Movies"
+                    "Admin-Analytics"
+                ],
+                "summary": "Sales Analytics (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tanggal tayang paling awal (YYYY-MM-DD, Default: 30 hari lalu)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal tayang paling akhir (YYYY-MM-DD, Default: hari ini)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "movie, schedule, cinema atau location (Default: movie)",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pembagian waktu: day atau week (Default: tanpa bucket)",
+                        "name": "bucket",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get All Movies (Admin)",
                 "responses": {}
             }
         },
-        "/admin/movies/{id}": {
+        "/admin/casts": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Tambah cast baru",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Casts"
+                ],
+                "summary": "Create Cast (Admin)",
+                "parameters": [
+                    {
+                        "description": "Cast Data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CastRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/casts/{id}": {
             "put": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Update data movie berdasarkan ID",
+                "description": "Ubah nama cast berdasarkan ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -48,24 +106,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin-Movies"
+                    "Admin-Casts"
                 ],
-                "summary": "Update Movie (Admin)",
+                "summary": "Rename Cast (Admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Movie ID",
+                        "description": "Cast ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Movie Update Data",
-                        "name": "movie",
+                        "description": "Cast Data",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateMovieRequest"
+                            "$ref": "#/definitions/models.CastRequest"
                         }
                     }
                 ],
@@ -77,26 +135,40 @@ const docTemplate = `{
                         "BearerToken": []
                     }
                 ],
-                "description": "Hapus movie berdasarkan ID",
+                "description": "Hapus cast, ditolak kalau masih dipakai movie kecuali force=true",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Admin-Movies"
+                    "Admin-Casts"
                 ],
-                "summary": "Delete Movie (Admin)",
+                "summary": "Delete Cast (Admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Movie ID",
+                        "description": "Cast ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hapus juga relasi ke movie",
+                        "name": "force",
+                        "in": "query"
                     }
                 ],
                 "responses": {}
             }
         },
-        "/auth/login": {
+        "/admin/cinemas": {
             "post": {
-                "description": "Login dengan email dan password, JWT disini",
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Tambah cinema baru, base_price dipakai sebagai harga tiket kalau schedule tidak punya harga sendiri",
                 "consumes": [
                     "application/json"
                 ],
@@ -104,26 +176,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin-Cinemas"
                 ],
-                "summary": "Login User",
+                "summary": "Create Cinema (Admin)",
                 "parameters": [
                     {
-                        "description": "Login Request",
+                        "description": "Cinema Data",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
+                            "$ref": "#/definitions/models.CinemaRequest"
                         }
                     }
                 ],
                 "responses": {}
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "Daftar User baru beserta profile",
+        "/admin/cinemas/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Ubah nama dan harga dasar cinema berdasarkan ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -131,84 +208,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin-Cinemas"
                 ],
-                "summary": "Register User",
+                "summary": "Update Cinema (Admin)",
                 "parameters": [
                     {
-                        "description": "Register Request",
+                        "type": "integer",
+                        "description": "Cinema ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cinema Data",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.RegisterRequest"
+                            "$ref": "#/definitions/models.CinemaRequest"
                         }
                     }
                 ],
                 "responses": {}
-            }
-        },
-        "/movies": {
-            "get": {
-                "description": "Ambil daftar film dengan pagination dan pencarian berdasarkan judul",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Movies"
-                ],
-                "summary": "Get Movies with Pagination and Search",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Halaman (Default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Jumlah data per halaman (Default: 10)",
-                        "name": "pagesize",
-                        "in": "query"
-                    },
+            },
+            "delete": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "Cari berdasarkan judul film",
-                        "name": "search",
-                        "in": "query"
+                        "BearerToken": []
                     }
                 ],
-                "responses": {}
-            }
-        },
-        "/movies/popular": {
-            "get": {
-                "description": "Popular Movies",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Movies"
-                ],
-                "summary": "Get Popular Movies",
-                "responses": {}
-            }
-        },
-        "/movies/schedules/{schedule_id}/seats": {
-            "get": {
-                "description": "kursi kosong berdasarkan schedule ID",
+                "description": "Hapus cinema, ditolak kalau masih dipakai schedule",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
+                    "Admin-Cinemas"
                 ],
-                "summary": "Get Available Seats",
+                "summary": "Delete Cinema (Admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Schedule ID",
-                        "name": "schedule_id",
+                        "description": "Cinema ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -216,71 +257,39 @@ const docTemplate = `{
                 "responses": {}
             }
         },
-        "/movies/upcoming": {
-            "get": {
-                "description": "Upcoming Movies",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Movies"
-                ],
-                "summary": "Get Upcoming Movies",
-                "responses": {}
-            }
-        },
-        "/movies/{id}": {
+        "/admin/cinemas/{id}/layout": {
             "get": {
-                "description": "Detail lengkap movie berdasarkan ID",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Movies"
-                ],
-                "summary": "Get Movie Detail",
-                "parameters": [
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "Movie ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "BearerToken": []
                     }
                 ],
-                "responses": {}
-            }
-        },
-        "/movies/{id}/schedules": {
-            "get": {
-                "description": "Get Schedule by Movie ID",
+                "description": "Layout kursi cinema, kosong kalau cinema masih memakai layout kursi default",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Movies"
+                    "Admin-Cinemas"
                 ],
-                "summary": "Get Schedule by Movie ID",
+                "summary": "Get Seat Layout (Admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Movie ID",
+                        "description": "Cinema ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {}
-            }
-        },
-        "/orders": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Buat pesanan baru",
+                "description": "Simpan layout kursi cinema (baris, kolom, kategori regular/vip/love_nest dan kursi yang diblokir).\nKursi yang tidak ada di layout baru dihapus, atau diblokir kalau sudah pernah dipesan.",
                 "consumes": [
                     "application/json"
                 ],
@@ -288,69 +297,386 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin-Cinemas"
                 ],
-                "summary": "Create a new Order",
+                "summary": "Update Seat Layout (Admin)",
                 "parameters": [
                     {
-                        "description": "Order Request",
+                        "type": "integer",
+                        "description": "Cinema ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Seat Layout",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateOrderExample"
+                            "$ref": "#/definitions/models.SeatLayoutRequest"
                         }
                     }
                 ],
                 "responses": {}
             }
         },
-        "/orders/user/{user_id}": {
+        "/admin/exports/orders": {
             "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Semua order milik user berdasarkan User ID",
+                "description": "Download semua order dalam CSV / XLSX dengan filter dan sorting yang sama seperti Get Orders (Admin), tanpa pagination",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin-Exports"
                 ],
-                "summary": "Get Order History by User",
+                "summary": "Export Orders (Admin)",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv atau xlsx (Default: csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal order paling awal (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal order paling akhir (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter film",
+                        "name": "movie_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter cinema",
+                        "name": "cinema_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter lokasi",
+                        "name": "location_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter metode pembayaran",
+                        "name": "payment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status order",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cari berdasarkan email user",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id, created_at, total_price, show_date atau status, prefix - untuk descending (Default: -created_at)",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {}
             }
         },
-        "/orders/{id}": {
+        "/admin/exports/sales": {
             "get": {
                 "security": [
                     {
                         "BearerToken": []
                     }
                 ],
-                "description": "Detail pesanan berdasarkan Order ID",
+                "description": "Download sales analytics dalam CSV / XLSX dengan filter yang sama seperti Sales Analytics, baris terakhir berisi total",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin-Exports"
                 ],
-                "summary": "Get Order Detail",
+                "summary": "Export Sales (Admin)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Order ID",
+                        "type": "string",
+                        "description": "csv atau xlsx (Default: csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal tayang paling awal (YYYY-MM-DD, Default: 30 hari lalu)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal tayang paling akhir (YYYY-MM-DD, Default: hari ini)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "movie, schedule, cinema atau location (Default: movie)",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pembagian waktu: day atau week (Default: tanpa bucket)",
+                        "name": "bucket",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/exports/schedules/{schedule_id}/manifest": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Download daftar kursi satu schedule beserta status dan data pemesan dalam CSV / XLSX",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Admin-Exports"
+                ],
+                "summary": "Export Seat Manifest (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv atau xlsx (Default: csv)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/genres": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Tambah genre baru",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Genres"
+                ],
+                "summary": "Create Genre (Admin)",
+                "parameters": [
+                    {
+                        "description": "Genre Data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GenreRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/genres/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Ubah nama genre berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Genres"
+                ],
+                "summary": "Rename Genre (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Genre ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Genre Data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GenreRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Hapus genre, ditolak kalau masih dipakai movie kecuali force=true",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Genres"
+                ],
+                "summary": "Delete Genre (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Genre ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Hapus juga relasi ke movie",
+                        "name": "force",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/locations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Tambah lokasi baru",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Cinemas"
+                ],
+                "summary": "Create Location (Admin)",
+                "parameters": [
+                    {
+                        "description": "Location Data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LocationRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/locations/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Ubah nama lokasi berdasarkan ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Cinemas"
+                ],
+                "summary": "Update Location (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location Data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LocationRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Hapus lokasi, ditolak kalau masih dipakai schedule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Cinemas"
+                ],
+                "summary": "Delete Location (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -359,87 +685,1903 @@ const docTemplate = `{
                 "responses": {}
             }
         },
-        "/profile": {
-            "get": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Data profil user login",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Profile"
-                ],
-                "summary": "Get User Profile",
-                "responses": {}
-            },
-            "put": {
-                "security": [
-                    {
-                        "BearerToken": []
-                    }
-                ],
-                "description": "Update data profil user yang sedang login",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Profile"
-                ],
-                "summary": "Update User Profile",
-                "parameters": [
-                    {
-                        "description": "Profile data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Profile"
-                        }
-                    }
-                ],
-                "responses": {}
-            }
-        }
-    },
-    "definitions": {
-        "models.CreateOrderExample": {
+        "/admin/movies": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Semua data Movie untuk admin",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Movies"
+                ],
+                "summary": "Get All Movies (Admin)",
+                "responses": {}
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Tambah movie baru beserta relasi genre dan cast",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Movies"
+                ],
+                "summary": "Create Movie (Admin)",
+                "parameters": [
+                    {
+                        "description": "Movie Data",
+                        "name": "movie",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateMovieRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/movies/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Import film dari file JSON / CSV berbentuk data TMDB. Film dicocokkan berdasarkan tmdb_id (dibuat atau diperbarui),\ngenre dan cast yang belum ada dibuat otomatis. Response berisi hasil created / updated / failed per baris.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Movies"
+                ],
+                "summary": "Import Movies (Admin)",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File JSON / CSV",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json atau csv (Default: dari ekstensi file)",
+                        "name": "format",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/movies/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update data movie berdasarkan ID, genre_ids / cast_ids opsional untuk mengganti relasi genre dan cast",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Movies"
+                ],
+                "summary": "Update Movie (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Movie Update Data",
+                        "name": "movie",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateMovieRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Hapus movie berdasarkan ID",
+                "tags": [
+                    "Admin-Movies"
+                ],
+                "summary": "Delete Movie (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/movies/{id}/backdrop": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Upload backdrop jpeg / png / webp (maksimal MOVIE_IMAGE_MAX_MB, default 5 MB). Disimpan dalam ukuran thumbnail,\nw500 dan original dengan nama berdasarkan hash isi file, backdrop movie diisi dengan path w500.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Movies"
+                ],
+                "summary": "Upload Movie Backdrop (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File gambar",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/movies/{id}/poster": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Upload poster jpeg / png / webp (maksimal MOVIE_IMAGE_MAX_MB, default 5 MB). Disimpan dalam ukuran thumbnail,\nw500 dan original dengan nama berdasarkan hash isi file, poster movie diisi dengan path w500.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Movies"
+                ],
+                "summary": "Upload Movie Poster (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File gambar",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Daftar semua order dengan filter, pagination dan sorting",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Orders"
+                ],
+                "summary": "Get Orders (Admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tanggal order paling awal (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal order paling akhir (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter film",
+                        "name": "movie_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter cinema",
+                        "name": "cinema_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter lokasi",
+                        "name": "location_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter metode pembayaran",
+                        "name": "payment_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status order",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cari berdasarkan email user",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "id, created_at, total_price, show_date atau status, prefix - untuk descending (Default: -created_at)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Halaman (Default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (Default: 10, maksimal 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Detail order lengkap dengan user, film, schedule, kursi, pembayaran dan refund",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Orders"
+                ],
+                "summary": "Get Order Detail (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/orders/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Batalkan order pending / paid tanpa melihat cancellation policy, order paid selalu di-refund penuh",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Orders"
+                ],
+                "summary": "Force Cancel Order (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alasan pembatalan",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ForceCancelOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/orders/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Ubah status order sesuai alur pending -\u003e paid -\u003e used, pending -\u003e expired / cancelled, paid -\u003e cancelled / refunded.\nUntuk membatalkan order sekaligus refund gunakan POST /admin/orders/{id}/cancel",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Orders"
+                ],
+                "summary": "Update Order Status (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status baru",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateOrderStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/payments/methods": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Daftar semua metode pembayaran termasuk yang nonaktif",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Payments"
+                ],
+                "summary": "Get All Payment Methods (Admin)",
+                "responses": {}
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Tambah metode pembayaran",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Payments"
+                ],
+                "summary": "Create Payment Method (Admin)",
+                "parameters": [
+                    {
+                        "description": "Payment Method Data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PaymentMethodRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/payments/methods/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Ubah nama, logo, urutan tampil atau aktifkan / nonaktifkan metode pembayaran",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Payments"
+                ],
+                "summary": "Update Payment Method (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment Method ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment Method Data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PaymentMethodRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Hapus metode pembayaran, ditolak kalau sudah dipakai order (nonaktifkan saja)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Payments"
+                ],
+                "summary": "Delete Payment Method (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment Method ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/schedules": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Tambah jadwal tayang, ditolak kalau bentrok dengan jadwal lain di cinema dan lokasi yang sama",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Schedules"
+                ],
+                "summary": "Create Schedule (Admin)",
+                "parameters": [
+                    {
+                        "description": "Schedule Data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/schedules/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Ubah jadwal tayang berdasarkan ID dengan validasi yang sama seperti saat membuat jadwal.\nKalau jadwal sudah punya order hanya price yang boleh diubah (selain itu 409).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Schedules"
+                ],
+                "summary": "Update Schedule (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule Data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Hapus jadwal tayang, ditolak kalau sudah ada order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Schedules"
+                ],
+                "summary": "Delete Schedule (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/times": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Tambah jam tayang baru (format 13:00)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Cinemas"
+                ],
+                "summary": "Create Time (Admin)",
+                "parameters": [
+                    {
+                        "description": "Time Data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TimeRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/times/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Ubah jam tayang, ditolak kalau sudah dipakai schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Cinemas"
+                ],
+                "summary": "Update Time (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Time ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Time Data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TimeRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Hapus jam tayang, ditolak kalau masih dipakai schedule",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Cinemas"
+                ],
+                "summary": "Delete Time (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Time ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Daftar user dengan pagination, pencarian email dan filter role",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Users"
+                ],
+                "summary": "Get Users (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman (Default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (Default: 10, maksimal 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cari berdasarkan email",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter role (admin, staff, user)",
+                        "name": "role",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/users/{id}/role": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Promote / demote role user, semua sesi user tersebut dicabut supaya role baru langsung berlaku",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Users"
+                ],
+                "summary": "Update User Role (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role baru",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateUserRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/users/{id}/sessions": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Cabut semua access token dan refresh token milik user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Users"
+                ],
+                "summary": "Revoke User Sessions (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/admin/users/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Nonaktifkan atau aktifkan kembali akun user, akun yang dinonaktifkan langsung kehilangan semua sesinya",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin-Users"
+                ],
+                "summary": "Enable / Disable User (Admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status akun",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateUserStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Login dengan email dan password, JWT disini",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login User",
+                "parameters": [
+                    {
+                        "description": "Login Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Cabut access token yang sedang dipakai, dan refresh token (beserta family-nya) kalau dikirim",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout User",
+                "parameters": [
+                    {
+                        "description": "Logout Request",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Tukar refresh token dengan access token baru, refresh token lama tidak bisa dipakai lagi (rotasi)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh Token",
+                "parameters": [
+                    {
+                        "description": "Refresh Token Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Daftar User baru beserta profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register User",
+                "parameters": [
+                    {
+                        "description": "Register Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/casts": {
+            "get": {
+                "description": "Daftar cast dengan pagination, bisa dicari berdasarkan nama",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Casts"
+                ],
+                "summary": "Get Casts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman (Default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (Default: 20)",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cari berdasarkan nama cast",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/cinemas": {
+            "get": {
+                "description": "Daftar cinema",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cinemas"
+                ],
+                "summary": "Get Cinemas",
+                "responses": {}
+            }
+        },
+        "/genres": {
+            "get": {
+                "description": "Daftar genre, bisa dicari berdasarkan nama",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Genres"
+                ],
+                "summary": "Get Genres",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cari berdasarkan nama genre",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/locations": {
+            "get": {
+                "description": "Daftar lokasi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cinemas"
+                ],
+                "summary": "Get Locations",
+                "responses": {}
+            }
+        },
+        "/movies": {
+            "get": {
+                "description": "Ambil daftar film dengan pagination dan pencarian berdasarkan judul",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get Movies with Pagination and Search",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman (Default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (Default: 10)",
+                        "name": "pagesize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cari berdasarkan judul film",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/movies/popular": {
+            "get": {
+                "description": "Popular Movies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get Popular Movies",
+                "responses": {}
+            }
+        },
+        "/movies/schedules/{schedule_id}/seat-map": {
+            "get": {
+                "description": "Denah kursi schedule per baris lengkap dengan kategori dan status (available, held, sold, blocked)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get Seat Map",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/movies/schedules/{schedule_id}/seats": {
+            "get": {
+                "description": "kursi kosong (belum dipesan, tidak sedang di-hold dan tidak diblokir) berdasarkan schedule ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get Available Seats",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/movies/upcoming": {
+            "get": {
+                "description": "Upcoming Movies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get Upcoming Movies",
+                "responses": {}
+            }
+        },
+        "/movies/{id}": {
+            "get": {
+                "description": "Detail lengkap movie berdasarkan ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get Movie Detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/movies/{id}/schedules": {
+            "get": {
+                "description": "Jadwal tayang movie lengkap dengan nama cinema, lokasi, jam tayang dan harga per kursi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movies"
+                ],
+                "summary": "Get Schedule by Movie ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal tayang (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter lokasi",
+                        "name": "location_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter cinema",
+                        "name": "cinema_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jam tayang paling awal (HH:MM)",
+                        "name": "time_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Jam tayang paling akhir (HH:MM)",
+                        "name": "time_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/orders": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Buat pesanan baru dari seat hold yang masih berlaku, total harga dihitung dari harga dasar schedule,\nkategori kursi dan aturan harga hari / jam tayang. Order dibuat dengan status pending\ndan otomatis expired kalau tidak dibayar sebelum expires_at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Create a new Order",
+                "parameters": [
+                    {
+                        "description": "Order Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/orders/holds": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Kunci kursi untuk schedule tertentu selama beberapa menit sebelum checkout",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Hold Seats",
+                "parameters": [
+                    {
+                        "description": "Hold Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateHoldRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/orders/holds/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Lepaskan hold kursi milik user login sebelum waktunya habis",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Release Seat Hold",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Hold ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/orders/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Riwayat order milik user yang sedang login dengan pagination, terbaru lebih dulu",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get My Orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Halaman (Default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah data per halaman (Default: 10, maksimal 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/orders/quote": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Hitung rincian harga (harga per kursi, fees, discounts dan total) sebelum checkout.\nValidasinya sama dengan Create Order tapi tidak ada data yang disimpan dan hold tetap berlaku.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Quote Order",
+                "parameters": [
+                    {
+                        "description": "Order Request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/orders/user/{user_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Semua order milik user berdasarkan User ID, hanya untuk user itu sendiri atau admin",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get Order History by User",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Detail pesanan berdasarkan Order ID beserta total harga dan rincian harga per kursi",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get Order Detail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/orders/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Batalkan order milik sendiri dan lepaskan kursinya. Order pending dibatalkan tanpa refund.\nOrder paid di-refund sesuai sisa waktu sebelum tayang: penuh sampai ORDER_CANCEL_FULL_REFUND_HOURS,\nsebagian (ORDER_CANCEL_PARTIAL_REFUND_PERCENT) sampai ORDER_CANCEL_PARTIAL_REFUND_HOURS, setelah itu ditolak.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Cancel Order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alasan pembatalan",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.CancelOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/orders/{id}/pay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Buat tagihan di payment gateway untuk order pending. Kalau tagihan pending sudah ada, tagihan itu yang dikembalikan.\n503 kalau server berjalan tanpa payment provider.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Pay Order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/orders/{id}/ticket.png": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "QR code (PNG) berisi token tiket yang ditandatangani: order, schedule, kursi dan masa berlaku.\nHanya tersedia untuk order yang sudah dibayar.",
+                "produces": [
+                    "image/png"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get Ticket QR",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/payments/fake/{charge_id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Simulasi pembayaran untuk fake provider (development). Status: succeeded atau failed.\nEndpoint ini mengirim webhook bertanda tangan ke alur yang sama dengan payment gateway asli.\nHanya pemilik order yang bisa membayar charge-nya.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Fake Checkout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Charge ID",
+                        "name": "charge_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Hasil pembayaran",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.FakePaymentRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/payments/methods": {
+            "get": {
+                "description": "Daftar metode pembayaran yang aktif, urut berdasarkan display_order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Get Payment Methods",
+                "responses": {}
+            }
+        },
+        "/payments/webhook": {
+            "post": {
+                "description": "Notifikasi status pembayaran dari payment gateway. Body ditandatangani HMAC-SHA256 (hex)\ndengan PAYMENT_WEBHOOK_SECRET dan dikirim lewat header X-Payment-Signature.\n503 kalau server berjalan tanpa payment provider.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payments"
+                ],
+                "summary": "Payment Webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "HMAC-SHA256 signature",
+                        "name": "X-Payment-Signature",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook Event",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payments.Event"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Data profil user login",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Get User Profile",
+                "responses": {}
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Update data profil user yang sedang login",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Update User Profile",
+                "parameters": [
+                    {
+                        "description": "Profile data",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Profile"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/profile/avatar": {
+            "put": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Upload avatar jpeg / png / webp (maksimal PROFILE_AVATAR_MAX_MB, default 2 MB).\nGambar dipotong persegi di bagian tengah lalu diperkecil menjadi 256x256, avatar lama dihapus.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Update User Avatar",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "File gambar",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/staff/checkin": {
+            "post": {
+                "security": [
+                    {
+                        "BearerToken": []
+                    }
+                ],
+                "description": "Scan token QR tiket di pintu studio. Tiket diterima kalau signature valid, order sudah dibayar,\njadwal tayang hari ini dan belum pernah check-in. Response selalu berisi accepted dan reason.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staff"
+                ],
+                "summary": "Ticket Check-in (Staff)",
+                "parameters": [
+                    {
+                        "description": "Token dari QR tiket",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CheckInRequest"
+                        }
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/times": {
+            "get": {
+                "description": "Daftar jam tayang",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cinemas"
+                ],
+                "summary": "Get Times",
+                "responses": {}
+            }
+        }
+    },
+    "definitions": {
+        "models.CancelOrderRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "tidak jadi nonton"
+                }
+            }
+        },
+        "models.CastRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Robert Downey Jr."
+                }
+            }
+        },
+        "models.CheckInRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "eyJvaWQiOjF9.c2lnbmF0dXJl"
+                }
+            }
+        },
+        "models.CinemaRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "base_price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 45000
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ebv.id"
+                }
+            }
+        },
+        "models.CreateHoldRequest": {
+            "type": "object",
+            "required": [
+                "schedule_id",
+                "seat_ids"
+            ],
+            "properties": {
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "seat_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                }
+            }
+        },
+        "models.CreateMovieRequest": {
+            "type": "object",
+            "required": [
+                "backdrop",
+                "director",
+                "duration",
+                "genre_ids",
+                "overview",
+                "poster",
+                "release_date",
+                "title"
+            ],
+            "properties": {
+                "backdrop": {
+                    "type": "string",
+                    "example": "https://image.tmdb.org/t/p/w500/backdrop.jpg"
+                },
+                "cast_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "director": {
+                    "type": "string",
+                    "example": "Anthony Russo, Joe Russo"
+                },
+                "duration": {
+                    "type": "integer",
+                    "example": 180
+                },
+                "genre_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "overview": {
+                    "type": "string",
+                    "example": "After the devastating events of Infinity War..."
+                },
+                "popularity": {
+                    "type": "number",
+                    "example": 95.6
+                },
+                "poster": {
+                    "type": "string",
+                    "example": "https://image.tmdb.org/t/p/w500/poster.jpg"
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "2019-04-26T00:00:00Z"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Avengers: Endgame"
+                }
+            }
+        },
+        "models.CreateOrderRequest": {
             "type": "object",
+            "required": [
+                "hold_id"
+            ],
             "properties": {
+                "hold_id": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "order": {
-                    "type": "object",
-                    "properties": {
-                        "email": {
-                            "type": "string",
-                            "example": "farid@mail.com"
-                        },
-                        "fullname": {
-                            "type": "string",
-                            "example": "Farid RD"
-                        },
-                        "payment_id": {
-                            "type": "integer",
-                            "example": 1
-                        },
-                        "phone": {
-                            "type": "string",
-                            "example": "08123456789"
-                        },
-                        "schedule_id": {
-                            "type": "integer",
-                            "example": 1
-                        },
-                        "user_id": {
-                            "type": "integer",
-                            "example": 1
-                        }
-                    }
+                    "$ref": "#/definitions/models.OrderContactRequest"
                 },
                 "seat_ids": {
                     "type": "array",
@@ -452,6 +2594,54 @@ const docTemplate = `{
                 }
             }
         },
+        "models.FakePaymentRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "succeeded"
+                }
+            }
+        },
+        "models.ForceCancelOrderRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "studio maintenance"
+                }
+            }
+        },
+        "models.GenreRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Action"
+                }
+            }
+        },
+        "models.LocationRequest": {
+            "type": "object",
+            "required": [
+                "location"
+            ],
+            "properties": {
+                "location": {
+                    "type": "string",
+                    "example": "Purwokerto"
+                }
+            }
+        },
         "models.LoginRequest": {
             "type": "object",
             "required": [
@@ -469,9 +2659,93 @@ const docTemplate = `{
                 }
             }
         },
+        "models.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "q3X0f1..."
+                }
+            }
+        },
+        "models.OrderContactRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "farid@mail.com"
+                },
+                "fullname": {
+                    "type": "string",
+                    "example": "Farid RD"
+                },
+                "payment_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "08123456789"
+                },
+                "schedule_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.OrderStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "paid",
+                "used",
+                "expired",
+                "cancelled",
+                "refunded"
+            ],
+            "x-enum-varnames": [
+                "OrderPending",
+                "OrderPaid",
+                "OrderUsed",
+                "OrderExpired",
+                "OrderCancelled",
+                "OrderRefunded"
+            ]
+        },
+        "models.PaymentMethodRequest": {
+            "type": "object",
+            "required": [
+                "is_active",
+                "name"
+            ],
+            "properties": {
+                "display_order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "logo_path": {
+                    "type": "string",
+                    "example": "/img/payments/bca.png"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "BCA"
+                }
+            }
+        },
         "models.Profile": {
             "type": "object",
             "properties": {
+                "avatar_url": {
+                    "description": "diisi lewat PUT /profile/avatar, diabaikan oleh PUT /profile",
+                    "type": "string",
+                    "readOnly": true,
+                    "example": "/img/avatars/1/3f2a9c_avatar.jpg"
+                },
                 "firstname": {
                     "type": "string",
                     "example": "Farid"
@@ -489,6 +2763,18 @@ const docTemplate = `{
                 }
             }
         },
+        "models.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "q3X0f1..."
+                }
+            }
+        },
         "models.RegisterRequest": {
             "type": "object",
             "required": [
@@ -515,10 +2801,126 @@ const docTemplate = `{
                 "phone_number": {
                     "type": "string",
                     "example": "08123456789"
+                }
+            }
+        },
+        "models.Role": {
+            "type": "string",
+            "enum": [
+                "admin",
+                "user",
+                "staff"
+            ],
+            "x-enum-varnames": [
+                "RoleAdmin",
+                "RoleUser",
+                "RoleStaff"
+            ]
+        },
+        "models.ScheduleRequest": {
+            "type": "object",
+            "required": [
+                "cinema_id",
+                "date",
+                "location_id",
+                "movie_id",
+                "time_id"
+            ],
+            "properties": {
+                "cinema_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "role": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-10-20"
+                },
+                "location_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "movie_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "price": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 50000
+                },
+                "time_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "models.SeatCategory": {
+            "type": "string",
+            "enum": [
+                "regular",
+                "vip",
+                "love_nest"
+            ],
+            "x-enum-varnames": [
+                "SeatRegular",
+                "SeatVIP",
+                "SeatLoveNest"
+            ]
+        },
+        "models.SeatLayoutItem": {
+            "type": "object",
+            "required": [
+                "column",
+                "row"
+            ],
+            "properties": {
+                "blocked": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "category": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SeatCategory"
+                        }
+                    ],
+                    "example": "regular"
+                },
+                "column": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "row": {
                     "type": "string",
-                    "example": "user"
+                    "example": "A"
+                }
+            }
+        },
+        "models.SeatLayoutRequest": {
+            "type": "object",
+            "required": [
+                "seats"
+            ],
+            "properties": {
+                "seats": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.SeatLayoutItem"
+                    }
+                }
+            }
+        },
+        "models.TimeRequest": {
+            "type": "object",
+            "required": [
+                "time"
+            ],
+            "properties": {
+                "time": {
+                    "type": "string",
+                    "example": "13:00"
                 }
             }
         },
@@ -539,6 +2941,16 @@ const docTemplate = `{
                     "type": "string",
                     "example": "https://image.tmdb.org/t/p/w500/backdrop.jpg"
                 },
+                "cast_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
                 "director": {
                     "type": "string",
                     "example": "Anthony Russo, Joe Russo"
@@ -547,6 +2959,17 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 180
                 },
+                "genre_ids": {
+                    "description": "kalau dikirim, genre dan cast movie diganti dengan daftar ini (array kosong = hapus semua)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
                 "overview": {
                     "type": "string",
                     "example": "After the devastating events of Infinity War..."
@@ -568,6 +2991,76 @@ const docTemplate = `{
                     "example": "Avengers: Endgame"
                 }
             }
+        },
+        "models.UpdateOrderStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.OrderStatus"
+                        }
+                    ],
+                    "example": "paid"
+                }
+            }
+        },
+        "models.UpdateUserRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Role"
+                        }
+                    ],
+                    "example": "admin"
+                }
+            }
+        },
+        "models.UpdateUserStatusRequest": {
+            "type": "object",
+            "required": [
+                "disabled"
+            ],
+            "properties": {
+                "disabled": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "payments.Event": {
+            "type": "object",
+            "properties": {
+                "charge_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/payments.Status"
+                }
+            }
+        },
+        "payments.Status": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "succeeded",
+                "failed",
+                "refunded"
+            ],
+            "x-enum-varnames": [
+                "StatusPending",
+                "StatusSucceeded",
+                "StatusFailed",
+                "StatusRefunded"
+            ]
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,164 @@
+package exports
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+func (f Format) IsValid() bool {
+	return f == FormatCSV || f == FormatXLSX
+}
+
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Writer menulis baris export satu per satu. Baris pertama biasanya header.
+// Close wajib dipanggil, untuk xlsx file baru benar-benar ditulis ke output saat Close.
+type Writer interface {
+	Write(row ...any) error
+	Close() error
+}
+
+// NewWriter membuat writer sesuai format, sheet hanya dipakai untuk xlsx
+func NewWriter(w io.Writer, format Format, sheet string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w, sheet)
+	}
+	return nil, fmt.Errorf("unsupported export format: %s", format)
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (cw *csvWriter) Write(row ...any) error {
+	record := make([]string, len(row))
+	for i, value := range row {
+		if value = cellValue(value); value != nil {
+			record[i] = fmt.Sprint(value)
+		}
+	}
+	return cw.w.Write(record)
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// xlsxWriter memakai StreamWriter excelize, baris yang sudah ditulis disimpan di file sementara.
+// Saat Close, zip workbook ditulis langsung ke output (lihat newXLSXWriter) sehingga export besar
+// tidak pernah ditahan utuh di memory.
+type xlsxWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXWriter(w io.Writer, sheet string) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	// WriteTo bawaan excelize membangun seluruh zip di bytes.Buffer dulu. Zip writer diarahkan ke output
+	// sehingga buffer itu tetap kosong dan isi workbook langsung mengalir ke response.
+	file.SetZipWriter(func(io.Writer) excelize.ZipWriter {
+		return zip.NewWriter(w)
+	})
+	if err := file.SetSheetName("Sheet1", sheet); err != nil {
+		file.Close()
+		return nil, err
+	}
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &xlsxWriter{out: w, file: file, stream: stream}, nil
+}
+
+func (xw *xlsxWriter) Write(row ...any) error {
+	xw.row++
+	cell, err := excelize.CoordinatesToCellName(1, xw.row)
+	if err != nil {
+		return err
+	}
+	values := make([]any, len(row))
+	for i, value := range row {
+		values[i] = cellValue(value)
+	}
+	return xw.stream.SetRow(cell, values)
+}
+
+func (xw *xlsxWriter) Close() error {
+	defer xw.file.Close()
+	if err := xw.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := xw.file.WriteTo(xw.out)
+	return err
+}
+
+// cellValue menyeragamkan nilai sel lalu meng-escape teks yang bisa dibaca sebagai formula spreadsheet
+func cellValue(value any) any {
+	value = plainValue(value)
+	if text, ok := value.(string); ok {
+		return escapeFormula(text)
+	}
+	return value
+}
+
+// escapeFormula memberi prefix ' pada teks yang diawali =, +, -, @, tab atau CR supaya input user
+// (nama, email) tidak dieksekusi sebagai formula saat file dibuka di spreadsheet
+func escapeFormula(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// plainValue: pointer nil jadi kosong, waktu jadi teks, slice digabung dengan koma
+func plainValue(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.DateTime)
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return v.Format(time.DateTime)
+	case *string:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case *int:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case []string:
+		return strings.Join(v, ", ")
+	case fmt.Stringer:
+		return v.String()
+	}
+	return value
+}
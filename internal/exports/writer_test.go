@@ -0,0 +1,91 @@
+package exports
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestCellValueEscapesFormulas(t *testing.T) {
+	name := "=HYPERLINK(\"http://evil\")"
+	tests := []struct {
+		value any
+		want  any
+	}{
+		{value: "=1+1", want: "'=1+1"},
+		{value: "+62812", want: "'+62812"},
+		{value: "-2", want: "'-2"},
+		{value: "@SUM(A1)", want: "'@SUM(A1)"},
+		{value: "\tcmd", want: "'\tcmd"},
+		{value: "\rcmd", want: "'\rcmd"},
+		{value: &name, want: "'" + name},
+		{value: []string{"=A1", "B2"}, want: "'=A1, B2"},
+		{value: "Farid", want: "Farid"},
+		{value: "a=b", want: "a=b"},
+		{value: "", want: ""},
+		{value: -5, want: -5},
+		{value: (*string)(nil), want: nil},
+	}
+	for _, tt := range tests {
+		if got := cellValue(tt.value); got != tt.want {
+			t.Errorf("cellValue(%#v) = %#v, want %#v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestWriters(t *testing.T) {
+	rows := [][]any{
+		{"order_id", "full_name", "total"},
+		{1, "=cmd|' /C calc'!A0", 50000},
+		{2, "Farid", 75000},
+	}
+	want := [][]string{
+		{"order_id", "full_name", "total"},
+		{"1", "'=cmd|' /C calc'!A0", "50000"},
+		{"2", "Farid", "75000"},
+	}
+
+	for _, format := range []Format{FormatCSV, FormatXLSX} {
+		t.Run(string(format), func(t *testing.T) {
+			var out bytes.Buffer
+			w, err := NewWriter(&out, format, "orders")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, row := range rows {
+				if err := w.Write(row...); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			var got [][]string
+			if format == FormatCSV {
+				for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+					got = append(got, strings.Split(line, ","))
+				}
+			} else {
+				file, err := excelize.OpenReader(&out)
+				if err != nil {
+					t.Fatalf("output is not a valid xlsx: %v", err)
+				}
+				defer file.Close()
+				if got, err = file.GetRows("orders"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if len(got) != len(want) {
+				t.Fatalf("rows = %q, want %q", got, want)
+			}
+			for i := range want {
+				if strings.Join(got[i], "|") != strings.Join(want[i], "|") {
+					t.Fatalf("row %d = %q, want %q", i, got[i], want[i])
+				}
+			}
+		})
+	}
+}
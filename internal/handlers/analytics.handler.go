@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+type AnalyticsHandler struct {
+	analyticsRepo *repositories.AnalyticsRepo
+}
+
+func NewAnalyticsHandler(analyticsRepo *repositories.AnalyticsRepo) *AnalyticsHandler {
+	return &AnalyticsHandler{analyticsRepo: analyticsRepo}
+}
+
+// GetSales godoc
+// @Summary     Sales Analytics (Admin)
+// @Description Revenue, tiket terjual dan okupansi per film / schedule / cinema / lokasi untuk schedule yang tayang di rentang tanggal.
+// @Description Hanya order paid dan used yang dihitung, revenue tidak termasuk service fee.
+// @Tags        Admin-Analytics
+// @Security    BearerToken
+// @Produce     json
+// @Param       date_from query string false "Tanggal tayang paling awal (YYYY-MM-DD, Default: 30 hari lalu)"
+// @Param       date_to   query string false "Tanggal tayang paling akhir (YYYY-MM-DD, Default: hari ini)"
+// @Param       group_by  query string false "movie, schedule, cinema atau location (Default: movie)"
+// @Param       bucket    query string false "Pembagian waktu: day atau week (Default: tanpa bucket)"
+// @Router      /admin/analytics/sales [get]
+func (ah *AnalyticsHandler) GetSales(ctx *gin.Context) {
+	filter, ok := parseAnalyticsFilter(ctx)
+	if !ok {
+		return
+	}
+
+	rows, err := ah.analyticsRepo.GetSales(ctx.Request.Context(), filter)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch sales analytics"})
+		return
+	}
+
+	report := models.SalesReport{
+		DateFrom: filter.DateFrom.Format(time.DateOnly),
+		DateTo:   filter.DateTo.Format(time.DateOnly),
+		GroupBy:  filter.GroupBy,
+		Bucket:   filter.Bucket,
+		Totals:   models.SumSales(rows),
+		Rows:     rows,
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": report})
+}
+
+// parseAnalyticsFilter membaca filter analytics dari query, juga dipakai oleh export sales.
+// Response 400 sudah dikirim kalau ok false.
+func parseAnalyticsFilter(ctx *gin.Context) (models.AnalyticsFilter, bool) {
+	today, _ := time.Parse(time.DateOnly, time.Now().Format(time.DateOnly))
+	filter := models.AnalyticsFilter{
+		DateFrom: today.AddDate(0, 0, -30),
+		DateTo:   today,
+		GroupBy:  models.AnalyticsGroup(ctx.DefaultQuery("group_by", string(models.GroupByMovie))),
+		Bucket:   models.AnalyticsBucket(ctx.Query("bucket")),
+	}
+	for _, param := range []struct {
+		key  string
+		dest *time.Time
+	}{{"date_from", &filter.DateFrom}, {"date_to", &filter.DateTo}} {
+		value := ctx.Query(param.key)
+		if value == "" {
+			continue
+		}
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid " + param.key + " format, use YYYY-MM-DD"})
+			return filter, false
+		}
+		*param.dest = date
+	}
+	if filter.DateFrom.After(filter.DateTo) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "date_from must not be after date_to"})
+		return filter, false
+	}
+	if !filter.GroupBy.IsValid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid group_by, use movie, schedule, cinema or location"})
+		return filter, false
+	}
+	if !filter.Bucket.IsValid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid bucket, use day or week"})
+		return filter, false
+	}
+	return filter, true
+}
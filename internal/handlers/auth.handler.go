@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"log"
 	"net/http"
 
@@ -11,11 +12,12 @@ import (
 )
 
 type AuthHandler struct {
-	authRepo *repositories.AuthRepo
+	authRepo  *repositories.AuthRepo
+	tokenRepo *repositories.TokenRepo
 }
 
-func NewAuthHandler(authRepo *repositories.AuthRepo) *AuthHandler {
-	return &AuthHandler{authRepo: authRepo}
+func NewAuthHandler(authRepo *repositories.AuthRepo, tokenRepo *repositories.TokenRepo) *AuthHandler {
+	return &AuthHandler{authRepo: authRepo, tokenRepo: tokenRepo}
 }
 
 // Login godoc
@@ -49,6 +51,11 @@ func (ah *AuthHandler) Login(ctx *gin.Context) {
 		return
 	}
 
+	if user.DisabledAt != nil {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "account is disabled"})
+		return
+	}
+
 	claim := pkg.NewJWTClaims(user.ID, string(user.Role))
 
 	token, err := claim.GenToken()
@@ -58,10 +65,29 @@ func (ah *AuthHandler) Login(ctx *gin.Context) {
 		return
 	}
 
+	familyID, err := pkg.GenTokenFamily()
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to generate token"})
+		return
+	}
+	refreshToken, err := pkg.GenRefreshToken()
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to generate token"})
+		return
+	}
+	if err := ah.tokenRepo.CreateRefreshToken(ctx, user.ID, familyID, pkg.HashRefreshToken(refreshToken), pkg.RefreshTokenTTL()); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to generate token"})
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{
 		"message": "Login Success",
 		"data": gin.H{
-			"token": token,
+			"token":         token,
+			"refresh_token": refreshToken,
 			"user": gin.H{
 				"id":    user.ID,
 				"email": user.Email,
@@ -71,6 +97,108 @@ func (ah *AuthHandler) Login(ctx *gin.Context) {
 	})
 }
 
+// Refresh godoc
+// @Summary     Refresh Token
+// @Description Tukar refresh token dengan access token baru, refresh token lama tidak bisa dipakai lagi (rotasi)
+// @Tags        Auth
+// @Accept      json
+// @Produce     json
+// @Param       body body models.RefreshTokenRequest true "Refresh Token Request"
+// @Router      /auth/refresh [post]
+func (ah *AuthHandler) Refresh(ctx *gin.Context) {
+	var body models.RefreshTokenRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request body"})
+		return
+	}
+
+	refreshToken, err := pkg.GenRefreshToken()
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to generate token"})
+		return
+	}
+
+	userID, err := ah.tokenRepo.RotateRefreshToken(ctx, pkg.HashRefreshToken(body.RefreshToken), pkg.HashRefreshToken(refreshToken), pkg.RefreshTokenTTL())
+	if err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, repositories.ErrRefreshTokenInvalid) || errors.Is(err, repositories.ErrRefreshTokenReused) {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"message": "Silahkan login kembali"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to refresh token"})
+		return
+	}
+
+	user, err := ah.authRepo.GetUserByID(ctx, userID)
+	if err != nil || user.DisabledAt != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "Silahkan login kembali"})
+		return
+	}
+
+	claim := pkg.NewJWTClaims(user.ID, string(user.Role))
+	token, err := claim.GenToken()
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to generate token"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "Refresh Success",
+		"data": gin.H{
+			"token":         token,
+			"refresh_token": refreshToken,
+		},
+	})
+}
+
+// Logout godoc
+// @Summary     Logout User
+// @Description Cabut access token yang sedang dipakai, dan refresh token (beserta family-nya) kalau dikirim
+// @Tags        Auth
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       body body models.LogoutRequest false "Logout Request"
+// @Router      /auth/logout [post]
+func (ah *AuthHandler) Logout(ctx *gin.Context) {
+	claims, ok := ctx.Get("claims")
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "unauthorized"})
+		return
+	}
+
+	userClaims, ok := claims.(*pkg.Claims)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "invalid claims"})
+		return
+	}
+
+	// body opsional, logout tetap jalan walaupun refresh token tidak dikirim
+	var body models.LogoutRequest
+	_ = ctx.ShouldBindJSON(&body)
+
+	if userClaims.ID != "" && userClaims.ExpiresAt != nil {
+		if err := ah.tokenRepo.RevokeAccessToken(ctx, userClaims.ID, userClaims.UserId, userClaims.ExpiresAt.Time); err != nil {
+			log.Println(err.Error())
+			ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to logout"})
+			return
+		}
+	}
+
+	if body.RefreshToken != "" {
+		if err := ah.tokenRepo.RevokeRefreshTokenFamily(ctx, pkg.HashRefreshToken(body.RefreshToken)); err != nil {
+			log.Println(err.Error())
+			ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to logout"})
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Logout Success"})
+}
+
 // Register godoc
 // @Summary     Register User
 // @Description Daftar User baru beserta profile
@@ -96,15 +224,11 @@ func (ah *AuthHandler) Register(ctx *gin.Context) {
 		return
 	}
 
-	role := body.Role
-	if role == "" {
-		role = "user"
-	}
-
+	// registrasi publik selalu membuat user biasa, role admin hanya bisa diberikan lewat /admin/users
 	user := models.User{
 		Email:    body.Email,
 		Password: hashed,
-		Role:     models.Role(role),
+		Role:     models.RoleUser,
 	}
 
 	newUser, err := ah.authRepo.RegisterUser(ctx, &user)
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+type CastHandler struct {
+	castRepo *repositories.CastRepo
+}
+
+func NewCastHandler(castRepo *repositories.CastRepo) *CastHandler {
+	return &CastHandler{castRepo: castRepo}
+}
+
+// GetCasts godoc
+// @Summary     Get Casts
+// @Description Daftar cast dengan pagination, bisa dicari berdasarkan nama
+// @Tags        Casts
+// @Produce     json
+// @Param       page      query int    false "Halaman (Default: 1)"
+// @Param       pageSize  query int    false "Jumlah data per halaman (Default: 20)"
+// @Param       search    query string false "Cari berdasarkan nama cast"
+// @Router      /casts [get]
+func (ch *CastHandler) GetCasts(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("pageSize", "20"))
+	search := ctx.DefaultQuery("search", "")
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+
+	casts, err := ch.castRepo.GetCasts(ctx, page, pageSize, search)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch casts"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"page":     page,
+		"pageSize": pageSize,
+		"search":   search,
+		"data":     casts,
+	})
+}
+
+// CreateCast godoc
+// @Summary     Create Cast (Admin)
+// @Description Tambah cast baru
+// @Tags        Admin-Casts
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       body body models.CastRequest true "Cast Data"
+// @Router      /admin/casts [post]
+func (ch *CastHandler) CreateCast(ctx *gin.Context) {
+	var req models.CastRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request", "error": err.Error()})
+		return
+	}
+
+	cast, err := ch.castRepo.CreateCast(ctx, req.Name)
+	if err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, repositories.ErrDuplicateName) {
+			ctx.JSON(http.StatusConflict, gin.H{"message": "cast already exists"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to create cast"})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"message": "cast created", "data": cast})
+}
+
+// UpdateCast godoc
+// @Summary     Rename Cast (Admin)
+// @Description Ubah nama cast berdasarkan ID
+// @Tags        Admin-Casts
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       id   path int                 true "Cast ID"
+// @Param       body body models.CastRequest true "Cast Data"
+// @Router      /admin/casts/{id} [put]
+func (ch *CastHandler) UpdateCast(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid cast id"})
+		return
+	}
+
+	var req models.CastRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request", "error": err.Error()})
+		return
+	}
+
+	if err := ch.castRepo.UpdateCast(ctx, id, req.Name); err != nil {
+		log.Println(err.Error())
+		switch {
+		case errors.Is(err, repositories.ErrDuplicateName):
+			ctx.JSON(http.StatusConflict, gin.H{"message": "cast already exists"})
+		case errors.Is(err, repositories.ErrCastNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "cast not found"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to update cast"})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "cast updated"})
+}
+
+// DeleteCast godoc
+// @Summary     Delete Cast (Admin)
+// @Description Hapus cast, ditolak kalau masih dipakai movie kecuali force=true
+// @Tags        Admin-Casts
+// @Security    BearerToken
+// @Produce     json
+// @Param       id    path  int  true  "Cast ID"
+// @Param       force query bool false "Hapus juga relasi ke movie"
+// @Router      /admin/casts/{id} [delete]
+func (ch *CastHandler) DeleteCast(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid cast id"})
+		return
+	}
+	force, _ := strconv.ParseBool(ctx.DefaultQuery("force", "false"))
+
+	if err := ch.castRepo.DeleteCast(ctx, id, force); err != nil {
+		log.Println(err.Error())
+		switch {
+		case errors.Is(err, repositories.ErrStillLinked):
+			ctx.JSON(http.StatusConflict, gin.H{"message": "cast is still linked to movies, use force=true to delete anyway"})
+		case errors.Is(err, repositories.ErrCastNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "cast not found"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to delete cast"})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "cast deleted"})
+}
@@ -0,0 +1,474 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+type CinemaHandler struct {
+	cinemaRepo *repositories.CinemaRepo
+}
+
+func NewCinemaHandler(cinemaRepo *repositories.CinemaRepo) *CinemaHandler {
+	return &CinemaHandler{cinemaRepo: cinemaRepo}
+}
+
+// normalizeShowTime menerima format "13:00", "13:00:00" atau "01:00pm" dan mengembalikan "15:04"
+func normalizeShowTime(value string) (string, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, layout := range []string{"15:04", "15:04:05", "03:04pm", "3:04pm"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format("15:04"), true
+		}
+	}
+	return "", false
+}
+
+// GetCinemas godoc
+// @Summary     Get Cinemas
+// @Description Daftar cinema
+// @Tags        Cinemas
+// @Produce     json
+// @Router      /cinemas [get]
+func (ch *CinemaHandler) GetCinemas(ctx *gin.Context) {
+	data, err := ch.cinemaRepo.GetCinemas(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch cinemas"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// CreateCinema godoc
+// @Summary     Create Cinema (Admin)
+// @Description Tambah cinema baru, base_price dipakai sebagai harga tiket kalau schedule tidak punya harga sendiri
+// @Tags        Admin-Cinemas
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       body body models.CinemaRequest true "Cinema Data"
+// @Router      /admin/cinemas [post]
+func (ch *CinemaHandler) CreateCinema(ctx *gin.Context) {
+	var req models.CinemaRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request", "error": err.Error()})
+		return
+	}
+
+	created, err := ch.cinemaRepo.CreateCinema(ctx, req.Name, req.BasePrice)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to create cinema"})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"message": "cinema created", "data": created})
+}
+
+// UpdateCinema godoc
+// @Summary     Update Cinema (Admin)
+// @Description Ubah nama dan harga dasar cinema berdasarkan ID
+// @Tags        Admin-Cinemas
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       id   path int true "Cinema ID"
+// @Param       body body models.CinemaRequest true "Cinema Data"
+// @Router      /admin/cinemas/{id} [put]
+func (ch *CinemaHandler) UpdateCinema(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid cinema id"})
+		return
+	}
+
+	var req models.CinemaRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request", "error": err.Error()})
+		return
+	}
+
+	if err := ch.cinemaRepo.UpdateCinema(ctx, id, req.Name, req.BasePrice); err != nil {
+		log.Println(err.Error())
+		switch {
+		case errors.Is(err, repositories.ErrCinemaNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "cinema not found"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to update cinema"})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "cinema updated"})
+}
+
+// DeleteCinema godoc
+// @Summary     Delete Cinema (Admin)
+// @Description Hapus cinema, ditolak kalau masih dipakai schedule
+// @Tags        Admin-Cinemas
+// @Security    BearerToken
+// @Produce     json
+// @Param       id path int true "Cinema ID"
+// @Router      /admin/cinemas/{id} [delete]
+func (ch *CinemaHandler) DeleteCinema(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid cinema id"})
+		return
+	}
+
+	if err := ch.cinemaRepo.DeleteCinema(ctx, id); err != nil {
+		log.Println(err.Error())
+		switch {
+		case errors.Is(err, repositories.ErrCinemaNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "cinema not found"})
+		case errors.Is(err, repositories.ErrStillScheduled):
+			ctx.JSON(http.StatusConflict, gin.H{"message": "cinema is still used by schedules"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to delete cinema"})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "cinema deleted"})
+}
+
+// GetSeatLayout godoc
+// @Summary     Get Seat Layout (Admin)
+// @Description Layout kursi cinema, kosong kalau cinema masih memakai layout kursi default
+// @Tags        Admin-Cinemas
+// @Security    BearerToken
+// @Produce     json
+// @Param       id path int true "Cinema ID"
+// @Router      /admin/cinemas/{id}/layout [get]
+func (ch *CinemaHandler) GetSeatLayout(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid cinema id"})
+		return
+	}
+
+	seats, err := ch.cinemaRepo.GetSeatLayout(ctx, id)
+	if err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, repositories.ErrCinemaNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "cinema not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch seat layout"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": seats})
+}
+
+// UpdateSeatLayout godoc
+// @Summary     Update Seat Layout (Admin)
+// @Description Simpan layout kursi cinema (baris, kolom, kategori regular/vip/love_nest dan kursi yang diblokir).
+// @Description Kursi yang tidak ada di layout baru dihapus, atau diblokir kalau sudah pernah dipesan.
+// @Tags        Admin-Cinemas
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       id   path int                      true "Cinema ID"
+// @Param       body body models.SeatLayoutRequest true "Seat Layout"
+// @Router      /admin/cinemas/{id}/layout [put]
+func (ch *CinemaHandler) UpdateSeatLayout(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid cinema id"})
+		return
+	}
+
+	var req models.SeatLayoutRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request", "error": err.Error()})
+		return
+	}
+	for i := range req.Seats {
+		seat := &req.Seats[i]
+		seat.Row = strings.ToUpper(strings.TrimSpace(seat.Row))
+		// seat_code dibentuk dari row + column, jadi row hanya boleh huruf
+		if seat.Row == "" || strings.Trim(seat.Row, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid seat row " + seat.Row + ", use letters only"})
+			return
+		}
+		if seat.Category == "" {
+			seat.Category = models.SeatRegular
+		}
+		if !seat.Category.IsValid() {
+			ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid seat category " + string(seat.Category)})
+			return
+		}
+	}
+
+	if err := ch.cinemaRepo.ReplaceSeatLayout(ctx, id, req.Seats); err != nil {
+		log.Println(err.Error())
+		var dupErr *repositories.DuplicateSeatError
+		switch {
+		case errors.As(err, &dupErr):
+			ctx.JSON(http.StatusBadRequest, gin.H{"message": dupErr.Error()})
+		case errors.Is(err, repositories.ErrCinemaNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "cinema not found"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to update seat layout"})
+		}
+		return
+	}
+
+	seats, err := ch.cinemaRepo.GetSeatLayout(ctx, id)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch seat layout"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "seat layout updated", "data": seats})
+}
+
+// GetLocations godoc
+// @Summary     Get Locations
+// @Description Daftar lokasi
+// @Tags        Cinemas
+// @Produce     json
+// @Router      /locations [get]
+func (ch *CinemaHandler) GetLocations(ctx *gin.Context) {
+	data, err := ch.cinemaRepo.GetLocations(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch locations"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// CreateLocation godoc
+// @Summary     Create Location (Admin)
+// @Description Tambah lokasi baru
+// @Tags        Admin-Cinemas
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       body body models.LocationRequest true "Location Data"
+// @Router      /admin/locations [post]
+func (ch *CinemaHandler) CreateLocation(ctx *gin.Context) {
+	var req models.LocationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request", "error": err.Error()})
+		return
+	}
+
+	created, err := ch.cinemaRepo.CreateLocation(ctx, req.Location)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to create location"})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"message": "location created", "data": created})
+}
+
+// UpdateLocation godoc
+// @Summary     Update Location (Admin)
+// @Description Ubah nama lokasi berdasarkan ID
+// @Tags        Admin-Cinemas
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       id   path int true "Location ID"
+// @Param       body body models.LocationRequest true "Location Data"
+// @Router      /admin/locations/{id} [put]
+func (ch *CinemaHandler) UpdateLocation(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid location id"})
+		return
+	}
+
+	var req models.LocationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request", "error": err.Error()})
+		return
+	}
+
+	if err := ch.cinemaRepo.UpdateLocation(ctx, id, req.Location); err != nil {
+		log.Println(err.Error())
+		switch {
+		case errors.Is(err, repositories.ErrLocationNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "location not found"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to update location"})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "location updated"})
+}
+
+// DeleteLocation godoc
+// @Summary     Delete Location (Admin)
+// @Description Hapus lokasi, ditolak kalau masih dipakai schedule
+// @Tags        Admin-Cinemas
+// @Security    BearerToken
+// @Produce     json
+// @Param       id path int true "Location ID"
+// @Router      /admin/locations/{id} [delete]
+func (ch *CinemaHandler) DeleteLocation(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid location id"})
+		return
+	}
+
+	if err := ch.cinemaRepo.DeleteLocation(ctx, id); err != nil {
+		log.Println(err.Error())
+		switch {
+		case errors.Is(err, repositories.ErrLocationNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "location not found"})
+		case errors.Is(err, repositories.ErrStillScheduled):
+			ctx.JSON(http.StatusConflict, gin.H{"message": "location is still used by schedules"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to delete location"})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "location deleted"})
+}
+
+// GetTimes godoc
+// @Summary     Get Times
+// @Description Daftar jam tayang
+// @Tags        Cinemas
+// @Produce     json
+// @Router      /times [get]
+func (ch *CinemaHandler) GetTimes(ctx *gin.Context) {
+	data, err := ch.cinemaRepo.GetTimes(ctx)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch times"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// CreateTime godoc
+// @Summary     Create Time (Admin)
+// @Description Tambah jam tayang baru (format 13:00)
+// @Tags        Admin-Cinemas
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       body body models.TimeRequest true "Time Data"
+// @Router      /admin/times [post]
+func (ch *CinemaHandler) CreateTime(ctx *gin.Context) {
+	var req models.TimeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request", "error": err.Error()})
+		return
+	}
+
+	value, ok := normalizeShowTime(req.Time)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid time format, use HH:MM"})
+		return
+	}
+
+	created, err := ch.cinemaRepo.CreateTime(ctx, value)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to create time slot"})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"message": "time slot created", "data": created})
+}
+
+// UpdateTime godoc
+// @Summary     Update Time (Admin)
+// @Description Ubah jam tayang, ditolak kalau sudah dipakai schedule
+// @Tags        Admin-Cinemas
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       id   path int true "Time ID"
+// @Param       body body models.TimeRequest true "Time Data"
+// @Router      /admin/times/{id} [put]
+func (ch *CinemaHandler) UpdateTime(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid time slot id"})
+		return
+	}
+
+	var req models.TimeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request", "error": err.Error()})
+		return
+	}
+
+	value, ok := normalizeShowTime(req.Time)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid time format, use HH:MM"})
+		return
+	}
+
+	if err := ch.cinemaRepo.UpdateTime(ctx, id, value); err != nil {
+		log.Println(err.Error())
+		switch {
+		case errors.Is(err, repositories.ErrTimeNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "time slot not found"})
+		case errors.Is(err, repositories.ErrStillScheduled):
+			ctx.JSON(http.StatusConflict, gin.H{"message": "time slot is still used by schedules"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to update time slot"})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "time slot updated"})
+}
+
+// DeleteTime godoc
+// @Summary     Delete Time (Admin)
+// @Description Hapus jam tayang, ditolak kalau masih dipakai schedule
+// @Tags        Admin-Cinemas
+// @Security    BearerToken
+// @Produce     json
+// @Param       id path int true "Time ID"
+// @Router      /admin/times/{id} [delete]
+func (ch *CinemaHandler) DeleteTime(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid time slot id"})
+		return
+	}
+
+	if err := ch.cinemaRepo.DeleteTime(ctx, id); err != nil {
+		log.Println(err.Error())
+		switch {
+		case errors.Is(err, repositories.ErrTimeNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "time slot not found"})
+		case errors.Is(err, repositories.ErrStillScheduled):
+			ctx.JSON(http.StatusConflict, gin.H{"message": "time slot is still used by schedules"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to delete time slot"})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "time slot deleted"})
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/exports"
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+type ExportHandler struct {
+	orderRepo     *repositories.OrderRepo
+	analyticsRepo *repositories.AnalyticsRepo
+}
+
+func NewExportHandler(orderRepo *repositories.OrderRepo, analyticsRepo *repositories.AnalyticsRepo) *ExportHandler {
+	return &ExportHandler{orderRepo: orderRepo, analyticsRepo: analyticsRepo}
+}
+
+// ExportOrders godoc
+// @Summary     Export Orders (Admin)
+// @Description Download semua order dalam CSV / XLSX dengan filter dan sorting yang sama seperti Get Orders (Admin), tanpa pagination
+// @Tags        Admin-Exports
+// @Security    BearerToken
+// @Produce     text/csv
+// @Produce     application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param       format      query string false "csv atau xlsx (Default: csv)"
+// @Param       date_from   query string false "Tanggal order paling awal (YYYY-MM-DD)"
+// @Param       date_to     query string false "Tanggal order paling akhir (YYYY-MM-DD)"
+// @Param       movie_id    query int    false "Filter film"
+// @Param       cinema_id   query int    false "Filter cinema"
+// @Param       location_id query int    false "Filter lokasi"
+// @Param       payment_id  query int    false "Filter metode pembayaran"
+// @Param       status      query string false "Filter status order"
+// @Param       email       query string false "Cari berdasarkan email user"
+// @Param       sort        query string false "id, created_at, total_price, show_date atau status, prefix - untuk descending (Default: -created_at)"
+// @Router      /admin/exports/orders [get]
+func (eh *ExportHandler) ExportOrders(ctx *gin.Context) {
+	format, ok := exportFormat(ctx)
+	if !ok {
+		return
+	}
+	filter, ok := parseAdminOrderFilter(ctx)
+	if !ok {
+		return
+	}
+
+	w, ok := startExport(ctx, format, "orders", "orders")
+	if !ok {
+		return
+	}
+	err := w.Write(
+		"Order ID", "User ID", "User Email", "Full Name", "Status", "Total Price",
+		"Movie", "Cinema", "Location", "Show Date", "Show Time", "Payment Method", "Seats", "Created At",
+	)
+	if err == nil {
+		err = eh.orderRepo.StreamOrdersForAdmin(ctx.Request.Context(), filter, func(o models.AdminOrderSummary) error {
+			return w.Write(
+				o.ID, o.UserID, o.UserEmail, o.FullName, string(o.Status), o.TotalPrice,
+				o.MovieTitle, o.CinemaName, o.Location, o.ShowDate.Format(time.DateOnly), o.ShowTime, o.PaymentMethod,
+				o.SeatCodes, o.CreatedAt,
+			)
+		})
+	}
+	finishExport(ctx, w, err)
+}
+
+// ExportSeatManifest godoc
+// @Summary     Export Seat Manifest (Admin)
+// @Description Download daftar kursi satu schedule beserta status dan data pemesan dalam CSV / XLSX
+// @Tags        Admin-Exports
+// @Security    BearerToken
+// @Produce     text/csv
+// @Produce     application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param       schedule_id path  int    true  "Schedule ID"
+// @Param       format      query string false "csv atau xlsx (Default: csv)"
+// @Router      /admin/exports/schedules/{schedule_id}/manifest [get]
+func (eh *ExportHandler) ExportSeatManifest(ctx *gin.Context) {
+	scheduleID, err := strconv.Atoi(ctx.Param("schedule_id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid schedule id"})
+		return
+	}
+	format, ok := exportFormat(ctx)
+	if !ok {
+		return
+	}
+
+	w, ok := startExport(ctx, format, fmt.Sprintf("manifest-schedule-%d", scheduleID), "manifest")
+	if !ok {
+		return
+	}
+	err = w.Write(
+		"Seat", "Row", "Column", "Category", "Status",
+		"Order ID", "Order Status", "Full Name", "Email", "Phone", "Price", "Admitted At",
+	)
+	if err == nil {
+		err = eh.orderRepo.StreamSeatManifest(ctx.Request.Context(), scheduleID, func(r models.SeatManifestRow) error {
+			var orderStatus *string
+			if r.OrderStatus != nil {
+				status := string(*r.OrderStatus)
+				orderStatus = &status
+			}
+			return w.Write(
+				r.SeatCode, r.Row, r.Column, string(r.Category), string(r.Status),
+				r.OrderID, orderStatus, r.FullName, r.Email, r.Phone, r.Price, r.AdmittedAt,
+			)
+		})
+	}
+	finishExport(ctx, w, err)
+}
+
+// ExportSales godoc
+// @Summary     Export Sales (Admin)
+// @Description Download sales analytics dalam CSV / XLSX dengan filter yang sama seperti Sales Analytics, baris terakhir berisi total
+// @Tags        Admin-Exports
+// @Security    BearerToken
+// @Produce     text/csv
+// @Produce     application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param       format    query string false "csv atau xlsx (Default: csv)"
+// @Param       date_from query string false "Tanggal tayang paling awal (YYYY-MM-DD, Default: 30 hari lalu)"
+// @Param       date_to   query string false "Tanggal tayang paling akhir (YYYY-MM-DD, Default: hari ini)"
+// @Param       group_by  query string false "movie, schedule, cinema atau location (Default: movie)"
+// @Param       bucket    query string false "Pembagian waktu: day atau week (Default: tanpa bucket)"
+// @Router      /admin/exports/sales [get]
+func (eh *ExportHandler) ExportSales(ctx *gin.Context) {
+	format, ok := exportFormat(ctx)
+	if !ok {
+		return
+	}
+	filter, ok := parseAnalyticsFilter(ctx)
+	if !ok {
+		return
+	}
+
+	// hasil sales sudah diagregasi per grup, jumlah barisnya kecil
+	rows, err := eh.analyticsRepo.GetSales(ctx.Request.Context(), filter)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch sales analytics"})
+		return
+	}
+
+	name := fmt.Sprintf("sales-%s-%s-%s", filter.GroupBy, filter.DateFrom.Format(time.DateOnly), filter.DateTo.Format(time.DateOnly))
+	w, ok := startExport(ctx, format, name, "sales")
+	if !ok {
+		return
+	}
+	err = w.Write("Bucket Start", "Group ID", "Group", "Schedules", "Revenue", "Tickets Sold", "Capacity", "Occupancy (%)")
+	for _, row := range rows {
+		if err != nil {
+			break
+		}
+		var bucket *string
+		if row.BucketStart != nil {
+			start := row.BucketStart.Format(time.DateOnly)
+			bucket = &start
+		}
+		err = w.Write(bucket, row.GroupID, row.GroupName, row.Schedules, row.Revenue, row.TicketsSold, row.Capacity, row.Occupancy)
+	}
+	if err == nil {
+		total := models.SumSales(rows)
+		err = w.Write(nil, nil, "Total", total.Schedules, total.Revenue, total.TicketsSold, total.Capacity, total.Occupancy)
+	}
+	finishExport(ctx, w, err)
+}
+
+// exportFormat membaca query format, default csv
+func exportFormat(ctx *gin.Context) (exports.Format, bool) {
+	format := exports.Format(ctx.DefaultQuery("format", string(exports.FormatCSV)))
+	if !format.IsValid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid format, use csv or xlsx"})
+		return "", false
+	}
+	return format, true
+}
+
+// startExport menyiapkan header download lalu membuat writer yang langsung menulis ke response
+func startExport(ctx *gin.Context, format exports.Format, name, sheet string) (exports.Writer, bool) {
+	w, err := exports.NewWriter(ctx.Writer, format, sheet)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to start export"})
+		return nil, false
+	}
+	ctx.Header("Content-Type", format.ContentType())
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+	return w, true
+}
+
+// finishExport menutup writer. Kalau gagal sebelum ada data yang terkirim response diganti JSON error,
+// kalau sudah terlanjur streaming (hanya csv, xlsx baru ditulis saat Close) response cukup dihentikan.
+func finishExport(ctx *gin.Context, w exports.Writer, err error) {
+	if err == nil {
+		err = w.Close()
+		if err == nil {
+			return
+		}
+	}
+	log.Println(err.Error())
+
+	if ctx.Writer.Written() {
+		ctx.Abort()
+		return
+	}
+	ctx.Writer.Header().Del("Content-Disposition")
+	ctx.Writer.Header().Del("Content-Type")
+	switch {
+	case errors.Is(err, repositories.ErrInvalidOrderSort):
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+	case errors.Is(err, repositories.ErrScheduleNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to export data"})
+	}
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+type GenreHandler struct {
+	genreRepo *repositories.GenreRepo
+}
+
+func NewGenreHandler(genreRepo *repositories.GenreRepo) *GenreHandler {
+	return &GenreHandler{genreRepo: genreRepo}
+}
+
+// GetGenres godoc
+// @Summary     Get Genres
+// @Description Daftar genre, bisa dicari berdasarkan nama
+// @Tags        Genres
+// @Produce     json
+// @Param       search query string false "Cari berdasarkan nama genre"
+// @Router      /genres [get]
+func (gh *GenreHandler) GetGenres(ctx *gin.Context) {
+	search := ctx.DefaultQuery("search", "")
+
+	genres, err := gh.genreRepo.GetGenres(ctx, search)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch genres"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": genres})
+}
+
+// CreateGenre godoc
+// @Summary     Create Genre (Admin)
+// @Description Tambah genre baru
+// @Tags        Admin-Genres
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       body body models.GenreRequest true "Genre Data"
+// @Router      /admin/genres [post]
+func (gh *GenreHandler) CreateGenre(ctx *gin.Context) {
+	var req models.GenreRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request", "error": err.Error()})
+		return
+	}
+
+	genre, err := gh.genreRepo.CreateGenre(ctx, req.Name)
+	if err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, repositories.ErrDuplicateName) {
+			ctx.JSON(http.StatusConflict, gin.H{"message": "genre already exists"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to create genre"})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"message": "genre created", "data": genre})
+}
+
+// UpdateGenre godoc
+// @Summary     Rename Genre (Admin)
+// @Description Ubah nama genre berdasarkan ID
+// @Tags        Admin-Genres
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       id   path int                 true "Genre ID"
+// @Param       body body models.GenreRequest true "Genre Data"
+// @Router      /admin/genres/{id} [put]
+func (gh *GenreHandler) UpdateGenre(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid genre id"})
+		return
+	}
+
+	var req models.GenreRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request", "error": err.Error()})
+		return
+	}
+
+	if err := gh.genreRepo.UpdateGenre(ctx, id, req.Name); err != nil {
+		log.Println(err.Error())
+		switch {
+		case errors.Is(err, repositories.ErrDuplicateName):
+			ctx.JSON(http.StatusConflict, gin.H{"message": "genre already exists"})
+		case errors.Is(err, repositories.ErrGenreNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "genre not found"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to update genre"})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "genre updated"})
+}
+
+// DeleteGenre godoc
+// @Summary     Delete Genre (Admin)
+// @Description Hapus genre, ditolak kalau masih dipakai movie kecuali force=true
+// @Tags        Admin-Genres
+// @Security    BearerToken
+// @Produce     json
+// @Param       id    path  int  true  "Genre ID"
+// @Param       force query bool false "Hapus juga relasi ke movie"
+// @Router      /admin/genres/{id} [delete]
+func (gh *GenreHandler) DeleteGenre(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid genre id"})
+		return
+	}
+	force, _ := strconv.ParseBool(ctx.DefaultQuery("force", "false"))
+
+	if err := gh.genreRepo.DeleteGenre(ctx, id, force); err != nil {
+		log.Println(err.Error())
+		switch {
+		case errors.Is(err, repositories.ErrStillLinked):
+			ctx.JSON(http.StatusConflict, gin.H{"message": "genre is still linked to movies, use force=true to delete anyway"})
+		case errors.Is(err, repositories.ErrGenreNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "genre not found"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to delete genre"})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "genre deleted"})
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/Darari17/be-go-tickitz-app/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type HoldHandler struct {
+	holdRepo *repositories.HoldRepo
+}
+
+func NewHoldHandler(holdRepo *repositories.HoldRepo) *HoldHandler {
+	return &HoldHandler{holdRepo: holdRepo}
+}
+
+// holdDuration dibaca dari env SEAT_HOLD_MINUTES, default 10 menit
+func holdDuration() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("SEAT_HOLD_MINUTES"))
+	if err != nil || minutes < 1 {
+		minutes = 10
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// CreateHold godoc
+// @Summary     Hold Seats
+// @Description Kunci kursi untuk schedule tertentu selama beberapa menit sebelum checkout
+// @Tags        Orders
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       body body models.CreateHoldRequest true "Hold Request"
+// @Router      /orders/holds [post]
+func (hh *HoldHandler) CreateHold(ctx *gin.Context) {
+	claims, ok := ctx.Get("claims")
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"message": "unauthorized",
+		})
+		return
+	}
+
+	userClaims, ok := claims.(*pkg.Claims)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"message": "invalid claims",
+		})
+		return
+	}
+
+	var req models.CreateHoldRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid request body",
+		})
+		return
+	}
+
+	hold, err := hh.holdRepo.CreateHold(ctx.Request.Context(), userClaims.UserId, req.ScheduleID, req.SeatIDs, holdDuration())
+	if err != nil {
+		log.Println(err.Error())
+		var takenErr *repositories.SeatTakenError
+		switch {
+		case errors.As(err, &takenErr):
+			ctx.JSON(http.StatusConflict, gin.H{
+				"status":     "error",
+				"message":    "seat already taken",
+				"seat_codes": takenErr.SeatCodes,
+			})
+		case errors.Is(err, repositories.ErrScheduleNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"status":  "error",
+				"message": err.Error(),
+			})
+		case errors.Is(err, repositories.ErrInvalidSeat):
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"status":  "error",
+				"message": err.Error(),
+			})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"status":  "error",
+				"message": "failed to hold seats",
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   hold,
+	})
+}
+
+// ReleaseHold godoc
+// @Summary     Release Seat Hold
+// @Description Lepaskan hold kursi milik user login sebelum waktunya habis
+// @Tags        Orders
+// @Security    BearerToken
+// @Produce     json
+// @Param       id path int true "Hold ID"
+// @Router      /orders/holds/{id} [delete]
+func (hh *HoldHandler) ReleaseHold(ctx *gin.Context) {
+	claims, ok := ctx.Get("claims")
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"message": "unauthorized",
+		})
+		return
+	}
+
+	userClaims, ok := claims.(*pkg.Claims)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"message": "invalid claims",
+		})
+		return
+	}
+
+	holdID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid hold id",
+		})
+		return
+	}
+
+	if err := hh.holdRepo.ReleaseHold(ctx.Request.Context(), userClaims.UserId, holdID); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, repositories.ErrHoldNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"status":  "error",
+				"message": err.Error(),
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "failed to release seat hold",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "seat hold released",
+	})
+}
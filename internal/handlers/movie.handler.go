@@ -1,21 +1,28 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/Darari17/be-go-tickitz-app/internal/imports"
+	"github.com/Darari17/be-go-tickitz-app/internal/media"
 	"github.com/Darari17/be-go-tickitz-app/internal/models"
 	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/Darari17/be-go-tickitz-app/internal/storage"
 	"github.com/gin-gonic/gin"
 )
 
 type MovieHandler struct {
 	movieRepo *repositories.MovieRepo
+	store     storage.Storage
 }
 
-func NewMovieHandler(movieRepo *repositories.MovieRepo) *MovieHandler {
-	return &MovieHandler{movieRepo: movieRepo}
+func NewMovieHandler(movieRepo *repositories.MovieRepo, store storage.Storage) *MovieHandler {
+	return &MovieHandler{movieRepo: movieRepo, store: store}
 }
 
 // GetUpcomingMovies godoc
@@ -91,10 +98,15 @@ func (mh *MovieHandler) GetMoviesWithPagination(ctx *gin.Context) {
 
 // GetSchedule godoc
 // @Summary     Get Schedule by Movie ID
-// @Description Get Schedule by Movie ID
+// @Description Jadwal tayang movie lengkap dengan nama cinema, lokasi, jam tayang dan harga per kursi
 // @Tags        Movies
 // @Produce     json
-// @Param       id path int true "Movie ID"
+// @Param       id          path  int    true  "Movie ID"
+// @Param       date        query string false "Tanggal tayang (YYYY-MM-DD)"
+// @Param       location_id query int    false "Filter lokasi"
+// @Param       cinema_id   query int    false "Filter cinema"
+// @Param       time_from   query string false "Jam tayang paling awal (HH:MM)"
+// @Param       time_to     query string false "Jam tayang paling akhir (HH:MM)"
 // @Router      /movies/{id}/schedules [get]
 func (mh *MovieHandler) GetSchedule(ctx *gin.Context) {
 	movieIDStr := ctx.Param("id")
@@ -105,7 +117,34 @@ func (mh *MovieHandler) GetSchedule(ctx *gin.Context) {
 		return
 	}
 
-	schedules, err := mh.movieRepo.GetSchedule(ctx, movieID)
+	var filter models.ScheduleFilter
+	if dateStr := ctx.Query("date"); dateStr != "" {
+		date, err := time.Parse(time.DateOnly, dateStr)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid date format, use YYYY-MM-DD"})
+			return
+		}
+		filter.Date = &date
+	}
+	filter.LocationID, _ = strconv.Atoi(ctx.Query("location_id"))
+	filter.CinemaID, _ = strconv.Atoi(ctx.Query("cinema_id"))
+	for _, param := range []struct {
+		key  string
+		dest *string
+	}{{"time_from", &filter.TimeFrom}, {"time_to", &filter.TimeTo}} {
+		value := ctx.Query(param.key)
+		if value == "" {
+			continue
+		}
+		normalized, ok := normalizeShowTime(value)
+		if !ok {
+			ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid " + param.key + " format, use HH:MM"})
+			return
+		}
+		*param.dest = normalized
+	}
+
+	schedules, err := mh.movieRepo.GetSchedule(ctx, movieID, filter)
 	if err != nil {
 		log.Println(err.Error())
 		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch schedule"})
@@ -116,7 +155,7 @@ func (mh *MovieHandler) GetSchedule(ctx *gin.Context) {
 
 // GetAvailableSeats godoc
 // @Summary     Get Available Seats
-// @Description kursi kosong berdasarkan schedule ID
+// @Description kursi kosong (belum dipesan, tidak sedang di-hold dan tidak diblokir) berdasarkan schedule ID
 // @Tags        Movies
 // @Produce     json
 // @Param       schedule_id path int true "Schedule ID"
@@ -139,6 +178,34 @@ func (mh *MovieHandler) GetAvailableSeats(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"data": seats})
 }
 
+// GetSeatMap godoc
+// @Summary     Get Seat Map
+// @Description Denah kursi schedule per baris lengkap dengan kategori dan status (available, held, sold, blocked)
+// @Tags        Movies
+// @Produce     json
+// @Param       schedule_id path int true "Schedule ID"
+// @Router      /movies/schedules/{schedule_id}/seat-map [get]
+func (mh *MovieHandler) GetSeatMap(ctx *gin.Context) {
+	scheduleID, err := strconv.Atoi(ctx.Param("schedule_id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid schedule id"})
+		return
+	}
+
+	seatMap, err := mh.movieRepo.GetSeatMap(ctx, scheduleID)
+	if err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, repositories.ErrScheduleNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "schedule not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch seat map"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": seatMap})
+}
+
 // GetMovieDetail godoc
 // @Summary     Get Movie Detail
 // @Description Detail lengkap movie berdasarkan ID
@@ -181,6 +248,102 @@ func (mh *MovieHandler) GetAllMovies(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"data": movies})
 }
 
+// CreateMovie godoc
+// @Summary     Create Movie (Admin)
+// @Description Tambah movie baru beserta relasi genre dan cast
+// @Tags        Admin-Movies
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       movie body models.CreateMovieRequest true "Movie Data"
+// @Router      /admin/movies [post]
+func (mh *MovieHandler) CreateMovie(ctx *gin.Context) {
+	var req models.CreateMovieRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request", "error": err.Error()})
+		return
+	}
+
+	movie := models.Movie{
+		Title:       req.Title,
+		Poster:      req.Poster,
+		Backdrop:    req.Backdrop,
+		Overview:    req.Overview,
+		ReleaseDate: req.ReleaseDate,
+		Duration:    req.Duration,
+		Director:    req.Director,
+		Popularity:  req.Popularity,
+	}
+
+	id, err := mh.movieRepo.CreateMovie(ctx, movie, req.GenreIDs, req.CastIDs)
+	if err != nil {
+		log.Println(err.Error())
+		var refErr *repositories.MissingReferenceError
+		if errors.As(err, &refErr) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"message": refErr.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to create movie"})
+		return
+	}
+
+	created, err := mh.movieRepo.GetMovieDetail(ctx, id)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch created movie"})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"message": "movie created", "data": created})
+}
+
+// ImportMovies godoc
+// @Summary     Import Movies (Admin)
+// @Description Import film dari file JSON / CSV berbentuk data TMDB. Film dicocokkan berdasarkan tmdb_id (dibuat atau diperbarui),
+// @Description genre dan cast yang belum ada dibuat otomatis. Response berisi hasil created / updated / failed per baris.
+// @Tags        Admin-Movies
+// @Security    BearerToken
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       file   formData file   true  "File JSON / CSV"
+// @Param       format formData string false "json atau csv (Default: dari ekstensi file)"
+// @Router      /admin/movies/import [post]
+func (mh *MovieHandler) ImportMovies(ctx *gin.Context) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "file is required"})
+		return
+	}
+
+	format := imports.Format(ctx.PostForm("format"))
+	if format == "" {
+		format, _ = imports.FormatFromFilename(fileHeader.Filename)
+	}
+	if format != imports.FormatJSON && format != imports.FormatCSV {
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid format, use json or csv"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to read file"})
+		return
+	}
+	defer file.Close()
+
+	records, err := imports.Parse(file, format)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid import file", "error": err.Error()})
+		return
+	}
+
+	report := imports.Run(ctx.Request.Context(), mh.movieRepo, records)
+	ctx.JSON(http.StatusOK, gin.H{"message": "import finished", "data": report})
+}
+
 // DeleteMovie godoc
 // @Summary     Delete Movie (Admin)
 // @Description Hapus movie berdasarkan ID
@@ -208,7 +371,7 @@ func (mh *MovieHandler) DeleteMovie(ctx *gin.Context) {
 
 // UpdateMovie godoc
 // @Summary     Update Movie (Admin)
-// @Description Update data movie berdasarkan ID
+// @Description Update data movie berdasarkan ID, genre_ids / cast_ids opsional untuk mengganti relasi genre dan cast
 // @Tags        Admin-Movies
 // @Security    BearerToken
 // @Accept      json
@@ -244,10 +407,130 @@ func (mh *MovieHandler) UpdateMovie(ctx *gin.Context) {
 		Popularity:  req.Popularity,
 	}
 
-	if err := mh.movieRepo.UpdateMovie(ctx, movie); err != nil {
+	if err := mh.movieRepo.UpdateMovie(ctx, movie, req.GenreIDs, req.CastIDs); err != nil {
 		log.Println(err.Error())
+		var refErr *repositories.MissingReferenceError
+		if errors.As(err, &refErr) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"message": refErr.Error()})
+			return
+		}
+		if errors.Is(err, repositories.ErrMovieNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "movie not found"})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to update movie"})
 		return
 	}
 	ctx.JSON(http.StatusOK, gin.H{"message": "movie updated"})
 }
+
+// UploadMoviePoster godoc
+// @Summary     Upload Movie Poster (Admin)
+// @Description Upload poster jpeg / png / webp (maksimal MOVIE_IMAGE_MAX_MB, default 5 MB). Disimpan dalam ukuran thumbnail,
+// @Description w500 dan original dengan nama berdasarkan hash isi file, poster movie diisi dengan path w500.
+// @Tags        Admin-Movies
+// @Security    BearerToken
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       id    path     int  true "Movie ID"
+// @Param       image formData file true "File gambar"
+// @Router      /admin/movies/{id}/poster [put]
+func (mh *MovieHandler) UploadMoviePoster(ctx *gin.Context) {
+	mh.uploadMovieImage(ctx, "poster")
+}
+
+// UploadMovieBackdrop godoc
+// @Summary     Upload Movie Backdrop (Admin)
+// @Description Upload backdrop jpeg / png / webp (maksimal MOVIE_IMAGE_MAX_MB, default 5 MB). Disimpan dalam ukuran thumbnail,
+// @Description w500 dan original dengan nama berdasarkan hash isi file, backdrop movie diisi dengan path w500.
+// @Tags        Admin-Movies
+// @Security    BearerToken
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       id    path     int  true "Movie ID"
+// @Param       image formData file true "File gambar"
+// @Router      /admin/movies/{id}/backdrop [put]
+func (mh *MovieHandler) UploadMovieBackdrop(ctx *gin.Context) {
+	mh.uploadMovieImage(ctx, "backdrop")
+}
+
+func (mh *MovieHandler) uploadMovieImage(ctx *gin.Context, kind string) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid movie id"})
+		return
+	}
+
+	data, ok := readImageUpload(ctx, "image", int64(envInt("MOVIE_IMAGE_MAX_MB", 5))<<20)
+	if !ok {
+		return
+	}
+
+	paths, err := media.SaveVariants(ctx.Request.Context(), mh.store, "movies", data, media.Thumbnail, media.W500, media.Original)
+	if err != nil {
+		log.Println(err.Error())
+		writeImageError(ctx, err)
+		return
+	}
+
+	if err := mh.movieRepo.UpdateMovieImage(ctx.Request.Context(), id, kind, paths[media.W500.Name]); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, repositories.ErrMovieNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "movie not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to update movie " + kind})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "movie " + kind + " updated", "data": paths})
+}
+
+// readImageUpload membaca file gambar dari multipart form dengan batas ukuran maxBytes.
+// Response error sudah dikirim kalau ok false.
+func readImageUpload(ctx *gin.Context, field string, maxBytes int64) ([]byte, bool) {
+	// sisa 1 MB untuk field dan boundary multipart lainnya
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBytes+1<<20)
+	fileHeader, err := ctx.FormFile(field)
+	if err != nil {
+		log.Println(err.Error())
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"message": media.ErrImageTooLarge.Error()})
+			return nil, false
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": field + " is required"})
+		return nil, false
+	}
+	if fileHeader.Size > maxBytes {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"message": media.ErrImageTooLarge.Error()})
+		return nil, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to read file"})
+		return nil, false
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxBytes))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to read file"})
+		return nil, false
+	}
+	return data, true
+}
+
+func writeImageError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, media.ErrUnsupportedImage):
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{"message": err.Error()})
+	case errors.Is(err, media.ErrImageTooLarge):
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"message": err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to save image"})
+	}
+}
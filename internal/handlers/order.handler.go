@@ -1,13 +1,18 @@
 package handlers
 
 import (
+	"errors"
 	"log"
 	"net/http"
+	"os"
 	"strconv"
+	"time"
 
 	"github.com/Darari17/be-go-tickitz-app/internal/models"
 	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/Darari17/be-go-tickitz-app/pkg"
 	"github.com/gin-gonic/gin"
+	"github.com/skip2/go-qrcode"
 )
 
 type OrderHandler struct {
@@ -18,16 +23,135 @@ func NewOrderHandler(orderRepo *repositories.OrderRepo) *OrderHandler {
 	return &OrderHandler{orderRepo: orderRepo}
 }
 
+// orderClaims mengambil claims user yang sedang login, user_id order selalu diambil dari sini
+func orderClaims(ctx *gin.Context) (*pkg.Claims, bool) {
+	claims, ok := ctx.Get("claims")
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"message": "unauthorized",
+		})
+		return nil, false
+	}
+
+	userClaims, ok := claims.(*pkg.Claims)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"status":  "error",
+			"message": "invalid claims",
+		})
+		return nil, false
+	}
+	return userClaims, true
+}
+
+// canAccessOrder: order hanya boleh dibaca pemiliknya atau admin
+func canAccessOrder(claims *pkg.Claims, ownerID int) bool {
+	return claims.Role == string(models.RoleAdmin) || claims.UserId == ownerID
+}
+
+// maxPageSize membatasi pageSize daftar yang dipaginasi (order, user) supaya satu request tidak menarik seluruh tabel
+const maxPageSize = 100
+
+// serviceFee adalah biaya layanan per tiket, dibaca dari env ORDER_SERVICE_FEE (default 0)
+func serviceFee() int {
+	return envInt("ORDER_SERVICE_FEE", 0)
+}
+
+// paymentWindow adalah batas waktu bayar order pending, dibaca dari env ORDER_PAYMENT_MINUTES (default 15 menit)
+func paymentWindow() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("ORDER_PAYMENT_MINUTES"))
+	if err != nil || minutes < 1 {
+		minutes = 15
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// envInt membaca env berupa angka >= 0, fallback kalau kosong atau tidak valid
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value < 0 {
+		return fallback
+	}
+	return value
+}
+
+// cancellationPolicy dibaca dari env ORDER_CANCEL_FULL_REFUND_HOURS (default 24),
+// ORDER_CANCEL_PARTIAL_REFUND_HOURS (default 3) dan ORDER_CANCEL_PARTIAL_REFUND_PERCENT (default 50).
+// Pembatalan lebih dari 24 jam sebelum tayang dikembalikan penuh, 3 - 24 jam dikembalikan 50%,
+// kurang dari 3 jam tidak bisa dibatalkan.
+func cancellationPolicy() models.CancellationPolicy {
+	return models.CancellationPolicy{
+		FullRefundBefore:     time.Duration(envInt("ORDER_CANCEL_FULL_REFUND_HOURS", 24)) * time.Hour,
+		PartialRefundBefore:  time.Duration(envInt("ORDER_CANCEL_PARTIAL_REFUND_HOURS", 3)) * time.Hour,
+		PartialRefundPercent: min(envInt("ORDER_CANCEL_PARTIAL_REFUND_PERCENT", 50), 100),
+	}
+}
+
+// QuoteOrder godoc
+// @Summary     Quote Order
+// @Description Hitung rincian harga (harga per kursi, fees, discounts dan total) sebelum checkout.
+// @Description Validasinya sama dengan Create Order tapi tidak ada data yang disimpan dan hold tetap berlaku.
+// @Tags        Orders
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       body body models.CreateOrderRequest true "Order Request"
+// @Router      /orders/quote [post]
+func (oh *OrderHandler) QuoteOrder(ctx *gin.Context) {
+	claims, ok := orderClaims(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.CreateOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid request body",
+		})
+		return
+	}
+
+	if len(req.SeatIDs) == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "at least one seat must be selected",
+		})
+		return
+	}
+
+	quote, err := oh.orderRepo.QuoteOrder(ctx.Request.Context(), req.Order.NewOrder(claims.UserId), req.HoldID, req.SeatIDs, serviceFee())
+	if err != nil {
+		log.Println(err.Error())
+		writeOrderError(ctx, err, "failed to quote order")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   quote,
+	})
+}
+
 // CreateOrder godoc
 // @Summary     Create a new Order
-// @Description Buat pesanan baru
+// @Description Buat pesanan baru dari seat hold yang masih berlaku, total harga dihitung dari harga dasar schedule,
+// @Description kategori kursi dan aturan harga hari / jam tayang. Order dibuat dengan status pending
+// @Description dan otomatis expired kalau tidak dibayar sebelum expires_at.
 // @Tags        Orders
 // @Security    BearerToken
 // @Accept      json
 // @Produce     json
-// @Param       body body models.CreateOrderExample true "Order Request"
+// @Param       body body models.CreateOrderRequest true "Order Request"
 // @Router      /orders [post]
 func (oh *OrderHandler) CreateOrder(ctx *gin.Context) {
+	claims, ok := orderClaims(ctx)
+	if !ok {
+		return
+	}
+
 	var req models.CreateOrderRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		log.Println(err.Error())
@@ -46,13 +170,10 @@ func (oh *OrderHandler) CreateOrder(ctx *gin.Context) {
 		return
 	}
 
-	newOrder, err := oh.orderRepo.CreateOrder(ctx.Request.Context(), &req.Order, req.SeatIDs)
+	newOrder, err := oh.orderRepo.CreateOrder(ctx.Request.Context(), req.Order.NewOrder(claims.UserId), req.HoldID, req.SeatIDs, serviceFee(), paymentWindow())
 	if err != nil {
 		log.Println(err.Error())
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"status":  "error",
-			"message": err.Error(),
-		})
+		writeOrderError(ctx, err, "failed to create order")
 		return
 	}
 
@@ -74,7 +195,7 @@ func (oh *OrderHandler) CreateOrder(ctx *gin.Context) {
 
 // GetOrderByID godoc
 // @Summary     Get Order Detail
-// @Description Detail pesanan berdasarkan Order ID
+// @Description Detail pesanan berdasarkan Order ID beserta total harga dan rincian harga per kursi
 // @Tags        Orders
 // @Security    BearerToken
 // @Produce     json
@@ -91,25 +212,127 @@ func (oh *OrderHandler) GetOrderByID(ctx *gin.Context) {
 		return
 	}
 
-	order, err := oh.orderRepo.GetOrderByID(ctx.Request.Context(), id)
+	order, ok := oh.ownedOrder(ctx, id)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   order,
+	})
+}
+
+// GetTicket godoc
+// @Summary     Get Ticket QR
+// @Description QR code (PNG) berisi token tiket yang ditandatangani: order, schedule, kursi dan masa berlaku.
+// @Description Hanya tersedia untuk order yang sudah dibayar.
+// @Tags        Orders
+// @Security    BearerToken
+// @Produce     png
+// @Param       id path int true "Order ID"
+// @Router      /orders/{id}/ticket.png [get]
+func (oh *OrderHandler) GetTicket(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
 	if err != nil {
 		log.Println(err.Error())
-		ctx.JSON(http.StatusNotFound, gin.H{
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid order id",
+		})
+		return
+	}
+
+	order, ok := oh.ownedOrder(ctx, id)
+	if !ok {
+		return
+	}
+	if order.Status != models.OrderPaid && order.Status != models.OrderUsed {
+		ctx.JSON(http.StatusConflict, gin.H{
 			"status":  "error",
-			"message": "order not found",
+			"message": "ticket is only available for paid orders",
 		})
 		return
 	}
 
+	token := order.QRCode
+	// order lama masih menyimpan qr_code placeholder, tokennya dibuat saat pertama kali diminta
+	_, err = pkg.VerifyTicket(token)
+	if errors.Is(err, pkg.ErrInvalidTicket) {
+		token, err = oh.orderRepo.IssueTicket(ctx.Request.Context(), order.ID)
+	}
+	// tiket yang sudah lewat jam tayang tetap ditampilkan
+	if err != nil && !errors.Is(err, pkg.ErrTicketExpired) {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "failed to issue ticket",
+		})
+		return
+	}
+
+	png, err := qrcode.Encode(token, qrcode.Medium, 320)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "failed to render ticket",
+		})
+		return
+	}
+	ctx.Header("Cache-Control", "private, no-store")
+	ctx.Data(http.StatusOK, "image/png", png)
+}
+
+// GetMyOrders godoc
+// @Summary     Get My Orders
+// @Description Riwayat order milik user yang sedang login dengan pagination, terbaru lebih dulu
+// @Tags        Orders
+// @Security    BearerToken
+// @Produce     json
+// @Param       page     query int false "Halaman (Default: 1)"
+// @Param       pageSize query int false "Jumlah data per halaman (Default: 10, maksimal 100)"
+// @Router      /orders/me [get]
+func (oh *OrderHandler) GetMyOrders(ctx *gin.Context) {
+	claims, ok := orderClaims(ctx)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("pageSize", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	pageSize = min(pageSize, maxPageSize)
+
+	orders, err := oh.orderRepo.GetOrdersByUserID(ctx.Request.Context(), claims.UserId, page, pageSize)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "failed to fetch orders",
+		})
+		return
+	}
+	if orders == nil {
+		orders = []models.Order{}
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{
-		"status": "success",
-		"data":   order,
+		"status":   "success",
+		"page":     page,
+		"pageSize": pageSize,
+		"data":     orders,
 	})
 }
 
 // GetOrdersByUser godoc
 // @Summary     Get Order History by User
-// @Description Semua order milik user berdasarkan User ID
+// @Description Semua order milik user berdasarkan User ID, hanya untuk user itu sendiri atau admin
 // @Tags        Orders
 // @Security    BearerToken
 // @Produce     json
@@ -126,7 +349,19 @@ func (oh *OrderHandler) GetOrdersByUser(ctx *gin.Context) {
 		return
 	}
 
-	orders, err := oh.orderRepo.GetOrdersByUserID(ctx.Request.Context(), userID)
+	claims, ok := orderClaims(ctx)
+	if !ok {
+		return
+	}
+	if !canAccessOrder(claims, userID) {
+		ctx.JSON(http.StatusForbidden, gin.H{
+			"status":  "error",
+			"message": "you can only view your own orders",
+		})
+		return
+	}
+
+	orders, err := oh.orderRepo.GetOrdersByUserID(ctx.Request.Context(), userID, 1, 0)
 	if err != nil {
 		log.Println(err.Error())
 		ctx.JSON(http.StatusInternalServerError, gin.H{
@@ -149,3 +384,259 @@ func (oh *OrderHandler) GetOrdersByUser(ctx *gin.Context) {
 		"data":   orders,
 	})
 }
+
+// GetOrdersForAdmin godoc
+// @Summary     Get Orders (Admin)
+// @Description Daftar semua order dengan filter, pagination dan sorting
+// @Tags        Admin-Orders
+// @Security    BearerToken
+// @Produce     json
+// @Param       date_from   query string false "Tanggal order paling awal (YYYY-MM-DD)"
+// @Param       date_to     query string false "Tanggal order paling akhir (YYYY-MM-DD)"
+// @Param       movie_id    query int    false "Filter film"
+// @Param       cinema_id   query int    false "Filter cinema"
+// @Param       location_id query int    false "Filter lokasi"
+// @Param       payment_id  query int    false "Filter metode pembayaran"
+// @Param       status      query string false "Filter status order"
+// @Param       email       query string false "Cari berdasarkan email user"
+// @Param       sort        query string false "id, created_at, total_price, show_date atau status, prefix - untuk descending (Default: -created_at)"
+// @Param       page        query int    false "Halaman (Default: 1)"
+// @Param       pageSize    query int    false "Jumlah data per halaman (Default: 10, maksimal 100)"
+// @Router      /admin/orders [get]
+func (oh *OrderHandler) GetOrdersForAdmin(ctx *gin.Context) {
+	filter, ok := parseAdminOrderFilter(ctx)
+	if !ok {
+		return
+	}
+
+	orders, total, err := oh.orderRepo.GetOrdersForAdmin(ctx.Request.Context(), filter)
+	if errors.Is(err, repositories.ErrInvalidOrderSort) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "failed to fetch orders",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status":   "success",
+		"page":     filter.Page,
+		"pageSize": filter.PageSize,
+		"total":    total,
+		"data":     orders,
+	})
+}
+
+// parseAdminOrderFilter membaca filter daftar order admin dari query, juga dipakai oleh export order.
+// Response 400 sudah dikirim kalau ok false.
+func parseAdminOrderFilter(ctx *gin.Context) (models.AdminOrderFilter, bool) {
+	filter := models.AdminOrderFilter{
+		Status: models.OrderStatus(ctx.Query("status")),
+		Email:  ctx.Query("email"),
+		Sort:   ctx.Query("sort"),
+	}
+	for _, param := range []struct {
+		key  string
+		dest **time.Time
+	}{{"date_from", &filter.DateFrom}, {"date_to", &filter.DateTo}} {
+		value := ctx.Query(param.key)
+		if value == "" {
+			continue
+		}
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"status":  "error",
+				"message": "invalid " + param.key + " format, use YYYY-MM-DD",
+			})
+			return filter, false
+		}
+		*param.dest = &date
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid order status",
+		})
+		return filter, false
+	}
+	filter.MovieID, _ = strconv.Atoi(ctx.Query("movie_id"))
+	filter.CinemaID, _ = strconv.Atoi(ctx.Query("cinema_id"))
+	filter.LocationID, _ = strconv.Atoi(ctx.Query("location_id"))
+	filter.PaymentID, _ = strconv.Atoi(ctx.Query("payment_id"))
+
+	filter.Page, _ = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	filter.PageSize, _ = strconv.Atoi(ctx.DefaultQuery("pageSize", "10"))
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = 10
+	}
+	filter.PageSize = min(filter.PageSize, maxPageSize)
+	return filter, true
+}
+
+// GetOrderDetailForAdmin godoc
+// @Summary     Get Order Detail (Admin)
+// @Description Detail order lengkap dengan user, film, schedule, kursi, pembayaran dan refund
+// @Tags        Admin-Orders
+// @Security    BearerToken
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Router      /admin/orders/{id} [get]
+func (oh *OrderHandler) GetOrderDetailForAdmin(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid order id",
+		})
+		return
+	}
+
+	detail, err := oh.orderRepo.GetOrderDetailForAdmin(ctx.Request.Context(), id)
+	if err != nil {
+		log.Println(err.Error())
+		writeOrderError(ctx, err, "failed to fetch order")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   detail,
+	})
+}
+
+// UpdateOrderStatus godoc
+// @Summary     Update Order Status (Admin)
+// @Description Ubah status order sesuai alur pending -> paid -> used, pending -> expired / cancelled, paid -> cancelled / refunded.
+// @Description Untuk membatalkan order sekaligus refund gunakan POST /admin/orders/{id}/cancel
+// @Tags        Admin-Orders
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       id   path int                             true "Order ID"
+// @Param       body body models.UpdateOrderStatusRequest true "Status baru"
+// @Router      /admin/orders/{id}/status [patch]
+func (oh *OrderHandler) UpdateOrderStatus(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid order id",
+		})
+		return
+	}
+
+	var req models.UpdateOrderStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid request body",
+		})
+		return
+	}
+	if !req.Status.IsValid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid order status",
+		})
+		return
+	}
+
+	if err := oh.orderRepo.UpdateOrderStatus(ctx.Request.Context(), id, req.Status); err != nil {
+		log.Println(err.Error())
+		writeOrderError(ctx, err, "failed to update order status")
+		return
+	}
+
+	order, err := oh.orderRepo.GetOrderByID(ctx.Request.Context(), id)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "failed to fetch updated order",
+		})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   order,
+	})
+}
+
+// ownedOrder mengambil order dan memastikan user yang login boleh mengaksesnya.
+// Order milik user lain dijawab 404 supaya keberadaan ID order tidak bocor.
+func (oh *OrderHandler) ownedOrder(ctx *gin.Context, id int) (*models.Order, bool) {
+	claims, ok := orderClaims(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	order, err := oh.orderRepo.GetOrderByID(ctx.Request.Context(), id)
+	if err == nil && !canAccessOrder(claims, order.UserID) {
+		err = repositories.ErrOrderNotFound
+	}
+	if err != nil {
+		log.Println(err.Error())
+		writeOrderError(ctx, err, "failed to fetch order")
+		return nil, false
+	}
+	return order, true
+}
+
+func writeOrderError(ctx *gin.Context, err error, fallback string) {
+	var takenErr *repositories.SeatTakenError
+	var transitionErr *repositories.OrderTransitionError
+	switch {
+	case errors.As(err, &takenErr):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"status":     "error",
+			"message":    "seat already taken",
+			"seat_codes": takenErr.SeatCodes,
+		})
+	case errors.As(err, &transitionErr):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"status":  "error",
+			"message": transitionErr.Error(),
+		})
+	case errors.Is(err, repositories.ErrScheduleNotFound), errors.Is(err, repositories.ErrOrderNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	case errors.Is(err, repositories.ErrHoldNotFound), errors.Is(err, repositories.ErrHoldMismatch),
+		errors.Is(err, repositories.ErrInvalidSeat), errors.Is(err, repositories.ErrPaymentMethodInactive):
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": err.Error(),
+		})
+	case errors.Is(err, repositories.ErrCancellationClosed):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"status":  "error",
+			"message": "order can no longer be cancelled",
+		})
+	case errors.Is(err, repositories.ErrScheduleUnpriced):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"status":  "error",
+			"message": "schedule has no price configured",
+		})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": fallback,
+		})
+	}
+}
@@ -0,0 +1,585 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/Darari17/be-go-tickitz-app/internal/payments"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentHandler struct {
+	paymentRepo *repositories.PaymentRepo
+	orderRepo   *repositories.OrderRepo
+	provider    payments.PaymentProvider
+}
+
+func NewPaymentHandler(paymentRepo *repositories.PaymentRepo, orderRepo *repositories.OrderRepo, provider payments.PaymentProvider) *PaymentHandler {
+	return &PaymentHandler{paymentRepo: paymentRepo, orderRepo: orderRepo, provider: provider}
+}
+
+// paymentEnabled membalas 503 kalau server jalan tanpa payment provider
+func (ph *PaymentHandler) paymentEnabled(ctx *gin.Context) bool {
+	if ph.provider == nil {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":  "error",
+			"message": "online payment is not available",
+		})
+		return false
+	}
+	return true
+}
+
+// PayOrder godoc
+// @Summary     Pay Order
+// @Description Buat tagihan di payment gateway untuk order pending. Kalau tagihan pending sudah ada, tagihan itu yang dikembalikan.
+// @Description 503 kalau server berjalan tanpa payment provider.
+// @Tags        Payments
+// @Security    BearerToken
+// @Produce     json
+// @Param       id path int true "Order ID"
+// @Router      /orders/{id}/pay [post]
+func (ph *PaymentHandler) PayOrder(ctx *gin.Context) {
+	if !ph.paymentEnabled(ctx) {
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid order id",
+		})
+		return
+	}
+
+	claims, ok := orderClaims(ctx)
+	if !ok {
+		return
+	}
+
+	order, err := ph.orderRepo.GetOrderByID(ctx.Request.Context(), id)
+	if err == nil && !canAccessOrder(claims, order.UserID) {
+		err = repositories.ErrOrderNotFound
+	}
+	if err != nil {
+		log.Println(err.Error())
+		writeOrderError(ctx, err, "failed to fetch order")
+		return
+	}
+	if order.Status != models.OrderPending || order.ExpiresAt == nil || !order.ExpiresAt.After(time.Now()) {
+		ctx.JSON(http.StatusConflict, gin.H{
+			"status":  "error",
+			"message": "order is not waiting for payment",
+		})
+		return
+	}
+
+	intent, err := ph.paymentRepo.GetPendingIntent(ctx.Request.Context(), order.ID)
+	if err == nil {
+		ctx.JSON(http.StatusOK, gin.H{
+			"status": "success",
+			"data":   intent,
+		})
+		return
+	}
+	if !errors.Is(err, repositories.ErrPaymentIntentNotFound) {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "failed to create payment",
+		})
+		return
+	}
+
+	charge, err := ph.provider.CreateCharge(ctx.Request.Context(), order.ID, order.TotalPrice)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadGateway, gin.H{
+			"status":  "error",
+			"message": "payment provider unavailable",
+		})
+		return
+	}
+
+	intent = &models.PaymentIntent{
+		OrderID:     order.ID,
+		Provider:    ph.provider.Name(),
+		ChargeID:    charge.ID,
+		Amount:      charge.Amount,
+		CheckoutURL: charge.CheckoutURL,
+		Status:      string(charge.Status),
+	}
+	if err := ph.paymentRepo.CreateIntent(ctx.Request.Context(), intent); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "failed to create payment",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   intent,
+	})
+}
+
+// PaymentWebhook godoc
+// @Summary     Payment Webhook
+// @Description Notifikasi status pembayaran dari payment gateway. Body ditandatangani HMAC-SHA256 (hex)
+// @Description dengan PAYMENT_WEBHOOK_SECRET dan dikirim lewat header X-Payment-Signature.
+// @Description 503 kalau server berjalan tanpa payment provider.
+// @Tags        Payments
+// @Accept      json
+// @Produce     json
+// @Param       X-Payment-Signature header string         true "HMAC-SHA256 signature"
+// @Param       body                body   payments.Event true "Webhook Event"
+// @Router      /payments/webhook [post]
+func (ph *PaymentHandler) PaymentWebhook(ctx *gin.Context) {
+	if !ph.paymentEnabled(ctx) {
+		return
+	}
+
+	payload, err := ctx.GetRawData()
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid request body",
+		})
+		return
+	}
+
+	event, err := ph.provider.ParseWebhook(payload, ctx.GetHeader("X-Payment-Signature"))
+	if err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, payments.ErrInvalidSignature) {
+			ctx.JSON(http.StatusUnauthorized, gin.H{
+				"status":  "error",
+				"message": "invalid signature",
+			})
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid webhook payload",
+		})
+		return
+	}
+
+	ph.applyEvent(ctx, event)
+}
+
+// FakeCheckout godoc
+// @Summary     Fake Checkout
+// @Description Simulasi pembayaran untuk fake provider (development). Status: succeeded atau failed.
+// @Description Endpoint ini mengirim webhook bertanda tangan ke alur yang sama dengan payment gateway asli.
+// @Description Hanya pemilik order yang bisa membayar charge-nya.
+// @Tags        Payments
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       charge_id path string                    true "Charge ID"
+// @Param       body      body models.FakePaymentRequest true "Hasil pembayaran"
+// @Router      /payments/fake/{charge_id} [post]
+func (ph *PaymentHandler) FakeCheckout(ctx *gin.Context) {
+	fake, ok := ph.provider.(*payments.FakeProvider)
+	if !ok {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "fake provider is not enabled",
+		})
+		return
+	}
+
+	claims, ok := orderClaims(ctx)
+	if !ok {
+		return
+	}
+
+	intent, err := ph.paymentRepo.GetIntentByChargeID(ctx.Request.Context(), ctx.Param("charge_id"))
+	var order *models.Order
+	if err == nil {
+		order, err = ph.orderRepo.GetOrderByID(ctx.Request.Context(), intent.OrderID)
+	}
+	if err == nil && order.UserID != claims.UserId {
+		err = repositories.ErrPaymentIntentNotFound
+	}
+	if err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, repositories.ErrPaymentIntentNotFound) || errors.Is(err, repositories.ErrOrderNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{
+				"status":  "error",
+				"message": "payment not found",
+			})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "failed to fetch payment",
+		})
+		return
+	}
+
+	var req models.FakePaymentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid request body",
+		})
+		return
+	}
+
+	payload, err := json.Marshal(payments.Event{ChargeID: intent.ChargeID, Status: payments.Status(req.Status)})
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "failed to build webhook",
+		})
+		return
+	}
+
+	event, err := fake.ParseWebhook(payload, fake.Sign(payload))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid payment status",
+		})
+		return
+	}
+
+	ph.applyEvent(ctx, event)
+}
+
+// applyEvent menyimpan status pembayaran. Pembayaran yang berhasil untuk order yang sudah tidak bisa dibayar
+// langsung dikembalikan ke customer, refund yang gagal tetap tercatat untuk diproses manual.
+func (ph *PaymentHandler) applyEvent(ctx *gin.Context, event *payments.Event) {
+	intent, refund, err := ph.paymentRepo.ApplyPaymentEvent(ctx.Request.Context(), event.ChargeID, string(event.Status))
+	var transitionErr *repositories.OrderTransitionError
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, gin.H{
+			"status": "success",
+			"data":   intent,
+		})
+	case errors.Is(err, repositories.ErrPaymentIntentNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "payment not found",
+		})
+	case errors.As(err, &transitionErr) && refund != nil:
+		log.Println(err.Error())
+		status := models.RefundSucceeded
+		message := "order can no longer be paid, payment refunded"
+		if _, err := ph.provider.Refund(ctx.Request.Context(), refund.ChargeID, refund.Amount); err != nil {
+			log.Println(err.Error())
+			status = models.RefundFailed
+			message = "order can no longer be paid, refund must be processed manually"
+		}
+		// kalau gagal disimpan, refund tetap tercatat sebagai pending
+		if err := ph.paymentRepo.CompleteRefund(ctx.Request.Context(), refund, status); err != nil {
+			log.Println(err.Error())
+		}
+		if refund.Status == models.RefundSucceeded {
+			intent.Status = string(payments.StatusRefunded)
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"status":  "success",
+			"message": message,
+			"data":    intent,
+		})
+	case errors.As(err, &transitionErr):
+		// contoh: refund untuk order yang sudah di-refund lewat aplikasi
+		log.Println(err.Error())
+		ctx.JSON(http.StatusOK, gin.H{
+			"status": "success",
+			"data":   intent,
+		})
+	default:
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "failed to process payment",
+		})
+	}
+}
+
+// CancelOrder godoc
+// @Summary     Cancel Order
+// @Description Batalkan order milik sendiri dan lepaskan kursinya. Order pending dibatalkan tanpa refund.
+// @Description Order paid di-refund sesuai sisa waktu sebelum tayang: penuh sampai ORDER_CANCEL_FULL_REFUND_HOURS,
+// @Description sebagian (ORDER_CANCEL_PARTIAL_REFUND_PERCENT) sampai ORDER_CANCEL_PARTIAL_REFUND_HOURS, setelah itu ditolak.
+// @Tags        Orders
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       id   path int                       true  "Order ID"
+// @Param       body body models.CancelOrderRequest false "Alasan pembatalan"
+// @Router      /orders/{id}/cancel [post]
+func (ph *PaymentHandler) CancelOrder(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid order id",
+		})
+		return
+	}
+
+	var req models.CancelOrderRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			log.Println(err.Error())
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"status":  "error",
+				"message": "invalid request body",
+			})
+			return
+		}
+	}
+
+	claims, ok := orderClaims(ctx)
+	if !ok {
+		return
+	}
+
+	order, err := ph.orderRepo.GetOrderByID(ctx.Request.Context(), id)
+	if err == nil && !canAccessOrder(claims, order.UserID) {
+		err = repositories.ErrOrderNotFound
+	}
+	if err != nil {
+		log.Println(err.Error())
+		writeOrderError(ctx, err, "failed to fetch order")
+		return
+	}
+
+	ph.cancelOrder(ctx, id, claims.UserId, req.Reason, false)
+}
+
+// ForceCancelOrder godoc
+// @Summary     Force Cancel Order (Admin)
+// @Description Batalkan order pending / paid tanpa melihat cancellation policy, order paid selalu di-refund penuh
+// @Tags        Admin-Orders
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       id   path int                            true "Order ID"
+// @Param       body body models.ForceCancelOrderRequest true "Alasan pembatalan"
+// @Router      /admin/orders/{id}/cancel [post]
+func (ph *PaymentHandler) ForceCancelOrder(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid order id",
+		})
+		return
+	}
+
+	var req models.ForceCancelOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "reason is required",
+		})
+		return
+	}
+
+	claims, ok := orderClaims(ctx)
+	if !ok {
+		return
+	}
+
+	ph.cancelOrder(ctx, id, claims.UserId, req.Reason, true)
+}
+
+// cancelOrder membatalkan order lalu mengembalikan dana lewat provider. Kalau provider gagal, tidak dikonfigurasi,
+// atau order dibayar tanpa payment gateway, order tetap cancelled dan refund dibiarkan untuk diproses manual.
+func (ph *PaymentHandler) cancelOrder(ctx *gin.Context, id, cancelledBy int, reason string, force bool) {
+	order, refund, err := ph.paymentRepo.CancelOrder(ctx.Request.Context(), id, cancellationPolicy(), cancelledBy, reason, force)
+	if err != nil {
+		log.Println(err.Error())
+		writeOrderError(ctx, err, "failed to cancel order")
+		return
+	}
+
+	message := "order cancelled"
+	if refund != nil {
+		status := models.RefundPending
+		if refund.PaymentIntentID != nil {
+			status = models.RefundFailed
+			if ph.provider == nil {
+				log.Println("payment provider is not configured, refund", refund.ID, "must be processed manually")
+			} else if _, err := ph.provider.Refund(ctx.Request.Context(), refund.ChargeID, refund.Amount); err != nil {
+				log.Println(err.Error())
+			} else {
+				status = models.RefundSucceeded
+			}
+			if err := ph.paymentRepo.CompleteRefund(ctx.Request.Context(), refund, status); err != nil {
+				log.Println(err.Error())
+			}
+		}
+		if status != models.RefundSucceeded {
+			message = "order cancelled, refund must be processed manually"
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": message,
+		"data":    models.CancelOrderResult{Order: order, Refund: refund},
+	})
+}
+
+// GetPaymentMethods godoc
+// @Summary     Get Payment Methods
+// @Description Daftar metode pembayaran yang aktif, urut berdasarkan display_order
+// @Tags        Payments
+// @Produce     json
+// @Router      /payments/methods [get]
+func (ph *PaymentHandler) GetPaymentMethods(ctx *gin.Context) {
+	methods, err := ph.paymentRepo.GetPaymentMethods(ctx, true)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch payment methods"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": methods})
+}
+
+// GetAllPaymentMethods godoc
+// @Summary     Get All Payment Methods (Admin)
+// @Description Daftar semua metode pembayaran termasuk yang nonaktif
+// @Tags        Admin-Payments
+// @Security    BearerToken
+// @Produce     json
+// @Router      /admin/payments/methods [get]
+func (ph *PaymentHandler) GetAllPaymentMethods(ctx *gin.Context) {
+	methods, err := ph.paymentRepo.GetPaymentMethods(ctx, false)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch payment methods"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": methods})
+}
+
+// CreatePaymentMethod godoc
+// @Summary     Create Payment Method (Admin)
+// @Description Tambah metode pembayaran
+// @Tags        Admin-Payments
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       body body models.PaymentMethodRequest true "Payment Method Data"
+// @Router      /admin/payments/methods [post]
+func (ph *PaymentHandler) CreatePaymentMethod(ctx *gin.Context) {
+	var req models.PaymentMethodRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request", "error": err.Error()})
+		return
+	}
+
+	method := models.PaymentMethod{
+		Name:         req.Name,
+		LogoPath:     req.LogoPath,
+		DisplayOrder: req.DisplayOrder,
+		IsActive:     *req.IsActive,
+	}
+	if err := ph.paymentRepo.CreatePaymentMethod(ctx, &method); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to create payment method"})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"message": "payment method created", "data": method})
+}
+
+// UpdatePaymentMethod godoc
+// @Summary     Update Payment Method (Admin)
+// @Description Ubah nama, logo, urutan tampil atau aktifkan / nonaktifkan metode pembayaran
+// @Tags        Admin-Payments
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       id   path int                         true "Payment Method ID"
+// @Param       body body models.PaymentMethodRequest true "Payment Method Data"
+// @Router      /admin/payments/methods/{id} [put]
+func (ph *PaymentHandler) UpdatePaymentMethod(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid payment method id"})
+		return
+	}
+
+	var req models.PaymentMethodRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request", "error": err.Error()})
+		return
+	}
+
+	method := models.PaymentMethod{
+		ID:           id,
+		Name:         req.Name,
+		LogoPath:     req.LogoPath,
+		DisplayOrder: req.DisplayOrder,
+		IsActive:     *req.IsActive,
+	}
+	if err := ph.paymentRepo.UpdatePaymentMethod(ctx, method); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, repositories.ErrPaymentMethodNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "payment method not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to update payment method"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "payment method updated", "data": method})
+}
+
+// DeletePaymentMethod godoc
+// @Summary     Delete Payment Method (Admin)
+// @Description Hapus metode pembayaran, ditolak kalau sudah dipakai order (nonaktifkan saja)
+// @Tags        Admin-Payments
+// @Security    BearerToken
+// @Produce     json
+// @Param       id path int true "Payment Method ID"
+// @Router      /admin/payments/methods/{id} [delete]
+func (ph *PaymentHandler) DeletePaymentMethod(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid payment method id"})
+		return
+	}
+
+	if err := ph.paymentRepo.DeletePaymentMethod(ctx, id); err != nil {
+		log.Println(err.Error())
+		switch {
+		case errors.Is(err, repositories.ErrPaymentMethodHasOrders):
+			ctx.JSON(http.StatusConflict, gin.H{"message": "payment method is used by orders, disable it instead"})
+		case errors.Is(err, repositories.ErrPaymentMethodNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "payment method not found"})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to delete payment method"})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "payment method deleted"})
+}
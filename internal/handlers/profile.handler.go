@@ -1,21 +1,26 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 
+	"github.com/Darari17/be-go-tickitz-app/internal/media"
 	"github.com/Darari17/be-go-tickitz-app/internal/models"
 	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/Darari17/be-go-tickitz-app/internal/storage"
 	"github.com/Darari17/be-go-tickitz-app/pkg"
 	"github.com/gin-gonic/gin"
 )
 
 type ProfileHandler struct {
 	profileRepo *repositories.ProfileRepo
+	store       storage.Storage
 }
 
-func NewProfileHandler(profileRepo *repositories.ProfileRepo) *ProfileHandler {
-	return &ProfileHandler{profileRepo: profileRepo}
+func NewProfileHandler(profileRepo *repositories.ProfileRepo, store storage.Storage) *ProfileHandler {
+	return &ProfileHandler{profileRepo: profileRepo, store: store}
 }
 
 // GetProfile godoc
@@ -92,3 +97,65 @@ func (ph *ProfileHandler) UpdateProfile(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, gin.H{"message": "profile updated"})
 }
+
+// UpdateAvatar godoc
+// @Summary     Update User Avatar
+// @Description Upload avatar jpeg / png / webp (maksimal PROFILE_AVATAR_MAX_MB, default 2 MB).
+// @Description Gambar dipotong persegi di bagian tengah lalu diperkecil menjadi 256x256, avatar lama dihapus.
+// @Tags        Profile
+// @Security    BearerToken
+// @Accept      multipart/form-data
+// @Produce     json
+// @Param       image formData file true "File gambar"
+// @Router      /profile/avatar [put]
+func (ph *ProfileHandler) UpdateAvatar(ctx *gin.Context) {
+	claims, ok := ctx.Get("claims")
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"message": "unauthorized",
+		})
+		return
+	}
+
+	userClaims, ok := claims.(*pkg.Claims)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, gin.H{
+			"message": "invalid claims",
+		})
+		return
+	}
+
+	data, ok := readImageUpload(ctx, "image", int64(envInt("PROFILE_AVATAR_MAX_MB", 2))<<20)
+	if !ok {
+		return
+	}
+
+	// folder per user supaya avatar yang sama milik user lain tidak ikut terhapus saat diganti
+	dir := fmt.Sprintf("avatars/%d", userClaims.UserId)
+	paths, err := media.SaveVariants(ctx.Request.Context(), ph.store, dir, data, media.Avatar)
+	if err != nil {
+		log.Println(err.Error())
+		writeImageError(ctx, err)
+		return
+	}
+	avatarURL := paths[media.Avatar.Name]
+
+	old, err := ph.profileRepo.UpdateAvatar(ctx.Request.Context(), userClaims.UserId, avatarURL)
+	if err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, repositories.ErrProfileNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "profile not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to update avatar"})
+		return
+	}
+
+	// upload gambar yang sama menghasilkan path yang sama, file itu tidak boleh dihapus
+	if old != nil && *old != avatarURL {
+		if err := ph.store.Delete(ctx.Request.Context(), *old); err != nil {
+			log.Println(err.Error())
+		}
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "avatar updated", "data": gin.H{"avatar_url": avatarURL}})
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+)
+
+type ScheduleHandler struct {
+	scheduleRepo *repositories.ScheduleRepo
+}
+
+func NewScheduleHandler(scheduleRepo *repositories.ScheduleRepo) *ScheduleHandler {
+	return &ScheduleHandler{scheduleRepo: scheduleRepo}
+}
+
+// CreateSchedule godoc
+// @Summary     Create Schedule (Admin)
+// @Description Tambah jadwal tayang, ditolak kalau bentrok dengan jadwal lain di cinema dan lokasi yang sama
+// @Tags        Admin-Schedules
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       body body models.ScheduleRequest true "Schedule Data"
+// @Router      /admin/schedules [post]
+func (sh *ScheduleHandler) CreateSchedule(ctx *gin.Context) {
+	schedule, ok := bindSchedule(ctx)
+	if !ok {
+		return
+	}
+
+	id, err := sh.scheduleRepo.CreateSchedule(ctx, schedule)
+	if err != nil {
+		log.Println(err.Error())
+		writeScheduleError(ctx, err, "failed to create schedule")
+		return
+	}
+
+	created, err := sh.scheduleRepo.GetScheduleByID(ctx, id)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch created schedule"})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"message": "schedule created", "data": created})
+}
+
+// UpdateSchedule godoc
+// @Summary     Update Schedule (Admin)
+// @Description Ubah jadwal tayang berdasarkan ID dengan validasi yang sama seperti saat membuat jadwal.
+// @Description Kalau jadwal sudah punya order hanya price yang boleh diubah (selain itu 409).
+// @Tags        Admin-Schedules
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       id   path int                    true "Schedule ID"
+// @Param       body body models.ScheduleRequest true "Schedule Data"
+// @Router      /admin/schedules/{id} [put]
+func (sh *ScheduleHandler) UpdateSchedule(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid schedule id"})
+		return
+	}
+
+	schedule, ok := bindSchedule(ctx)
+	if !ok {
+		return
+	}
+	schedule.ID = id
+
+	if err := sh.scheduleRepo.UpdateSchedule(ctx, schedule); err != nil {
+		log.Println(err.Error())
+		writeScheduleError(ctx, err, "failed to update schedule")
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "schedule updated"})
+}
+
+// DeleteSchedule godoc
+// @Summary     Delete Schedule (Admin)
+// @Description Hapus jadwal tayang, ditolak kalau sudah ada order
+// @Tags        Admin-Schedules
+// @Security    BearerToken
+// @Produce     json
+// @Param       id path int true "Schedule ID"
+// @Router      /admin/schedules/{id} [delete]
+func (sh *ScheduleHandler) DeleteSchedule(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid schedule id"})
+		return
+	}
+
+	if err := sh.scheduleRepo.DeleteSchedule(ctx, id); err != nil {
+		log.Println(err.Error())
+		writeScheduleError(ctx, err, "failed to delete schedule")
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "schedule deleted"})
+}
+
+func bindSchedule(ctx *gin.Context) (models.Schedule, bool) {
+	var req models.ScheduleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request", "error": err.Error()})
+		return models.Schedule{}, false
+	}
+
+	date, err := time.Parse(time.DateOnly, req.Date)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid date format, use YYYY-MM-DD"})
+		return models.Schedule{}, false
+	}
+
+	return models.Schedule{
+		MovieID:    req.MovieID,
+		CinemaID:   req.CinemaID,
+		LocationID: req.LocationID,
+		TimeID:     req.TimeID,
+		Date:       date,
+		Price:      req.Price,
+	}, true
+}
+
+func writeScheduleError(ctx *gin.Context, err error, fallback string) {
+	var refErr *repositories.MissingReferenceError
+	var conflictErr *repositories.ScheduleConflictError
+	switch {
+	case errors.As(err, &refErr):
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": refErr.Error()})
+	case errors.As(err, &conflictErr):
+		ctx.JSON(http.StatusConflict, gin.H{
+			"message":     conflictErr.Error(),
+			"schedule_id": conflictErr.ScheduleID,
+		})
+	case errors.Is(err, repositories.ErrScheduleNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"message": "schedule not found"})
+	case errors.Is(err, repositories.ErrScheduleHasOrders):
+		ctx.JSON(http.StatusConflict, gin.H{"message": "schedule already has orders"})
+	default:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": fallback})
+	}
+}
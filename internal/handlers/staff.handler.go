@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/Darari17/be-go-tickitz-app/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type StaffHandler struct {
+	orderRepo *repositories.OrderRepo
+}
+
+func NewStaffHandler(orderRepo *repositories.OrderRepo) *StaffHandler {
+	return &StaffHandler{orderRepo: orderRepo}
+}
+
+// CheckIn godoc
+// @Summary     Ticket Check-in (Staff)
+// @Description Scan token QR tiket di pintu studio. Tiket diterima kalau signature valid, order sudah dibayar,
+// @Description jadwal tayang hari ini dan belum pernah check-in. Response selalu berisi accepted dan reason.
+// @Tags        Staff
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       body body models.CheckInRequest true "Token dari QR tiket"
+// @Router      /staff/checkin [post]
+func (sh *StaffHandler) CheckIn(ctx *gin.Context) {
+	var req models.CheckInRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "invalid request body",
+		})
+		return
+	}
+
+	ticket, err := pkg.VerifyTicket(req.Token)
+	if errors.Is(err, pkg.ErrTicketSecretMissing) {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "failed to verify ticket",
+		})
+		return
+	}
+	if err != nil {
+		reason := models.CheckInInvalidTicket
+		if errors.Is(err, pkg.ErrTicketExpired) {
+			reason = models.CheckInTicketExpired
+		}
+		result := models.CheckInResult{Reason: reason}
+		if ticket != nil {
+			result.OrderID, result.ScheduleID, result.Seats = ticket.OrderID, ticket.ScheduleID, ticket.Seats
+		}
+		writeCheckInResult(ctx, &result)
+		return
+	}
+
+	claims, _ := ctx.Get("claims")
+	staff, _ := claims.(*pkg.Claims)
+
+	result, err := sh.orderRepo.CheckIn(ctx.Request.Context(), *ticket, staff.UserId)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "failed to check in ticket",
+		})
+		return
+	}
+	writeCheckInResult(ctx, result)
+}
+
+func writeCheckInResult(ctx *gin.Context, result *models.CheckInResult) {
+	if result.Accepted {
+		ctx.JSON(http.StatusOK, gin.H{
+			"status": "success",
+			"data":   result,
+		})
+		return
+	}
+
+	code := http.StatusConflict
+	switch result.Reason {
+	case models.CheckInInvalidTicket, models.CheckInTicketMismatch:
+		code = http.StatusBadRequest
+	case models.CheckInOrderNotFound:
+		code = http.StatusNotFound
+	}
+	ctx.JSON(code, gin.H{
+		"status":  "error",
+		"message": "ticket rejected: " + string(result.Reason),
+		"data":    result,
+	})
+}
@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/Darari17/be-go-tickitz-app/pkg"
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	userRepo  *repositories.UserRepo
+	tokenRepo *repositories.TokenRepo
+}
+
+func NewUserHandler(userRepo *repositories.UserRepo, tokenRepo *repositories.TokenRepo) *UserHandler {
+	return &UserHandler{userRepo: userRepo, tokenRepo: tokenRepo}
+}
+
+// GetUsers godoc
+// @Summary     Get Users (Admin)
+// @Description Daftar user dengan pagination, pencarian email dan filter role
+// @Tags        Admin-Users
+// @Security    BearerToken
+// @Produce     json
+// @Param       page      query int    false "Halaman (Default: 1)"
+// @Param       pageSize  query int    false "Jumlah data per halaman (Default: 10, maksimal 100)"
+// @Param       search    query string false "Cari berdasarkan email"
+// @Param       role      query string false "Filter role (admin, staff, user)"
+// @Router      /admin/users [get]
+func (uh *UserHandler) GetUsers(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(ctx.DefaultQuery("pageSize", "10"))
+	search := ctx.DefaultQuery("search", "")
+	role := ctx.DefaultQuery("role", "")
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	pageSize = min(pageSize, maxPageSize)
+	if role != "" && !models.Role(role).IsValid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid role"})
+		return
+	}
+
+	users, err := uh.userRepo.GetUsers(ctx, page, pageSize, search, role)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch users"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"page":     page,
+		"pageSize": pageSize,
+		"search":   search,
+		"data":     users,
+	})
+}
+
+// UpdateUserRole godoc
+// @Summary     Update User Role (Admin)
+// @Description Promote / demote role user, semua sesi user tersebut dicabut supaya role baru langsung berlaku
+// @Tags        Admin-Users
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       id   path int                          true "User ID"
+// @Param       body body models.UpdateUserRoleRequest true "Role baru"
+// @Router      /admin/users/{id}/role [patch]
+func (uh *UserHandler) UpdateUserRole(ctx *gin.Context) {
+	id, ok := uh.targetUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateUserRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request"})
+		return
+	}
+	if !req.Role.IsValid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid role"})
+		return
+	}
+
+	if err := uh.userRepo.UpdateRole(ctx, id, req.Role); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "user not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to update role"})
+		return
+	}
+
+	if err := uh.tokenRepo.RevokeUserSessions(ctx, id); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "role updated but failed to revoke sessions"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "role updated"})
+}
+
+// UpdateUserStatus godoc
+// @Summary     Enable / Disable User (Admin)
+// @Description Nonaktifkan atau aktifkan kembali akun user, akun yang dinonaktifkan langsung kehilangan semua sesinya
+// @Tags        Admin-Users
+// @Security    BearerToken
+// @Accept      json
+// @Produce     json
+// @Param       id   path int                            true "User ID"
+// @Param       body body models.UpdateUserStatusRequest true "Status akun"
+// @Router      /admin/users/{id}/status [patch]
+func (uh *UserHandler) UpdateUserStatus(ctx *gin.Context) {
+	id, ok := uh.targetUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req models.UpdateUserStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid request"})
+		return
+	}
+
+	if err := uh.userRepo.SetDisabled(ctx, id, *req.Disabled); err != nil {
+		log.Println(err.Error())
+		if errors.Is(err, repositories.ErrUserNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"message": "user not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to update user status"})
+		return
+	}
+
+	if !*req.Disabled {
+		ctx.JSON(http.StatusOK, gin.H{"message": "user enabled"})
+		return
+	}
+
+	if err := uh.tokenRepo.RevokeUserSessions(ctx, id); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "user disabled but failed to revoke sessions"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "user disabled"})
+}
+
+// RevokeUserSessions godoc
+// @Summary     Revoke User Sessions (Admin)
+// @Description Cabut semua access token dan refresh token milik user
+// @Tags        Admin-Users
+// @Security    BearerToken
+// @Produce     json
+// @Param       id path int true "User ID"
+// @Router      /admin/users/{id}/sessions [delete]
+func (uh *UserHandler) RevokeUserSessions(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid user id"})
+		return
+	}
+
+	exists, err := uh.userRepo.UserExists(ctx, id)
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to revoke sessions"})
+		return
+	}
+	if !exists {
+		ctx.JSON(http.StatusNotFound, gin.H{"message": "user not found"})
+		return
+	}
+
+	if err := uh.tokenRepo.RevokeUserSessions(ctx, id); err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to revoke sessions"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "all sessions revoked"})
+}
+
+// targetUserID membaca path param id dan menolak admin yang mengubah akunnya sendiri
+func (uh *UserHandler) targetUserID(ctx *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		log.Println(err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "invalid user id"})
+		return 0, false
+	}
+
+	claims, _ := ctx.Get("claims")
+	if userClaims, ok := claims.(*pkg.Claims); ok && userClaims.UserId == id {
+		ctx.JSON(http.StatusForbidden, gin.H{"message": "cannot change your own account"})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,323 @@
+package imports
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+)
+
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+)
+
+// FormatFromFilename menebak format dari ekstensi file
+func FormatFromFilename(name string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return FormatJSON, true
+	case ".csv":
+		return FormatCSV, true
+	}
+	return "", false
+}
+
+// tmdbGenres adalah daftar genre film resmi TMDB, dipakai kalau file hanya berisi genre_ids
+// (misalnya hasil endpoint discover / popular)
+var tmdbGenres = map[int]string{
+	28: "Action", 12: "Adventure", 16: "Animation", 35: "Comedy", 80: "Crime",
+	99: "Documentary", 18: "Drama", 10751: "Family", 14: "Fantasy", 36: "History",
+	27: "Horror", 10402: "Music", 9648: "Mystery", 10749: "Romance", 878: "Science Fiction",
+	10770: "TV Movie", 53: "Thriller", 10752: "War", 37: "Western",
+}
+
+// maxCasts membatasi jumlah cast yang diambil dari credits TMDB
+const maxCasts = 10
+
+// Record adalah satu baris file import. Err terisi kalau baris tidak bisa dibaca,
+// baris lain tetap diproses.
+type Record struct {
+	Row   int
+	Movie models.MovieImport
+	Err   error
+}
+
+type tmdbNamed struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Job  string `json:"job"`
+}
+
+// tmdbMovie mengikuti bentuk response movie detail TMDB (append_to_response=credits)
+// maupun item hasil list (genre_ids tanpa credits)
+type tmdbMovie struct {
+	ID           int         `json:"id"`
+	Title        string      `json:"title"`
+	Overview     string      `json:"overview"`
+	PosterPath   string      `json:"poster_path"`
+	BackdropPath string      `json:"backdrop_path"`
+	Popularity   float64     `json:"popularity"`
+	ReleaseDate  string      `json:"release_date"`
+	Runtime      int         `json:"runtime"`
+	Genres       []tmdbNamed `json:"genres"`
+	GenreIDs     []int       `json:"genre_ids"`
+	Credits      struct {
+		Cast []tmdbNamed `json:"cast"`
+		Crew []tmdbNamed `json:"crew"`
+	} `json:"credits"`
+	// bukan field TMDB, untuk file yang disusun manual
+	Director string   `json:"director"`
+	Casts    []string `json:"casts"`
+}
+
+// Parse membaca file import. JSON boleh berupa array movie, satu object movie,
+// atau response list TMDB ({"results": [...]}).
+// CSV wajib punya header: tmdb_id (atau id), title, overview, poster_path, backdrop_path,
+// popularity, release_date, runtime, director, genres dan casts (dipisah "|").
+func Parse(r io.Reader, format Format) ([]Record, error) {
+	switch format {
+	case FormatJSON:
+		return parseJSON(r)
+	case FormatCSV:
+		return parseCSV(r)
+	}
+	return nil, fmt.Errorf("unsupported import format: %s", format)
+}
+
+func parseJSON(r io.Reader) ([]Record, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []json.RawMessage
+	trimmed := strings.TrimSpace(string(raw))
+	switch {
+	case strings.HasPrefix(trimmed, "["):
+		err = json.Unmarshal(raw, &items)
+	case strings.HasPrefix(trimmed, "{"):
+		var list struct {
+			Results []json.RawMessage `json:"results"`
+		}
+		if err = json.Unmarshal(raw, &list); err == nil {
+			items = list.Results
+			if items == nil {
+				items = []json.RawMessage{raw}
+			}
+		}
+	default:
+		err = errors.New("json must be an array or an object")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, 0, len(items))
+	for i, item := range items {
+		record := Record{Row: i + 1}
+		var m tmdbMovie
+		if err := json.Unmarshal(item, &m); err != nil {
+			record.Err = err
+		} else {
+			record.Movie, record.Err = m.toImport()
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (m tmdbMovie) toImport() (models.MovieImport, error) {
+	movie := models.MovieImport{
+		TMDBID:     m.ID,
+		Title:      strings.TrimSpace(m.Title),
+		Overview:   m.Overview,
+		Poster:     m.PosterPath,
+		Backdrop:   m.BackdropPath,
+		Popularity: m.Popularity,
+		Duration:   m.Runtime,
+		Director:   m.Director,
+		Casts:      m.Casts,
+	}
+
+	for _, g := range m.Genres {
+		movie.Genres = append(movie.Genres, g.Name)
+	}
+	for _, id := range m.GenreIDs {
+		name, ok := tmdbGenres[id]
+		if !ok {
+			return movie, fmt.Errorf("unknown tmdb genre id: %d", id)
+		}
+		movie.Genres = append(movie.Genres, name)
+	}
+	for i, c := range m.Credits.Cast {
+		if i == maxCasts {
+			break
+		}
+		movie.Casts = append(movie.Casts, c.Name)
+	}
+	if movie.Director == "" {
+		var directors []string
+		for _, c := range m.Credits.Crew {
+			if c.Job == "Director" {
+				directors = append(directors, c.Name)
+			}
+		}
+		movie.Director = strings.Join(directors, ", ")
+	}
+
+	return movie, finishImport(&movie, m.ReleaseDate)
+}
+
+func parseCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["tmdb_id"]; !ok {
+		if i, ok := columns["id"]; ok {
+			columns["tmdb_id"] = i
+		}
+	}
+	for _, required := range []string{"tmdb_id", "title"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("csv header must contain %s", required)
+		}
+	}
+
+	var records []Record
+	for row := 1; ; row++ {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		record := Record{Row: row}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			record.Err = err
+			records = append(records, record)
+			continue
+		}
+		record.Movie, record.Err = csvMovie(columns, fields)
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func csvMovie(columns map[string]int, fields []string) (models.MovieImport, error) {
+	get := func(name string) string {
+		if i, ok := columns[name]; ok && i < len(fields) {
+			return strings.TrimSpace(fields[i])
+		}
+		return ""
+	}
+	list := func(name string) []string {
+		var values []string
+		for _, value := range strings.Split(get(name), "|") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+
+	movie := models.MovieImport{
+		Title:    get("title"),
+		Overview: get("overview"),
+		Poster:   get("poster_path"),
+		Backdrop: get("backdrop_path"),
+		Director: get("director"),
+		Genres:   list("genres"),
+		Casts:    list("casts"),
+	}
+
+	var err error
+	if movie.TMDBID, err = strconv.Atoi(get("tmdb_id")); err != nil {
+		return movie, errors.New("invalid tmdb_id")
+	}
+	if value := get("popularity"); value != "" {
+		if movie.Popularity, err = strconv.ParseFloat(value, 64); err != nil {
+			return movie, errors.New("invalid popularity")
+		}
+	}
+	if value := get("runtime"); value != "" {
+		if movie.Duration, err = strconv.Atoi(value); err != nil {
+			return movie, errors.New("invalid runtime")
+		}
+	}
+	return movie, finishImport(&movie, get("release_date"))
+}
+
+// finishImport memvalidasi field wajib dan membaca release_date (YYYY-MM-DD)
+func finishImport(movie *models.MovieImport, releaseDate string) error {
+	if movie.TMDBID <= 0 {
+		return errors.New("tmdb_id is required")
+	}
+	if movie.Title == "" {
+		return errors.New("title is required")
+	}
+	if movie.Duration < 0 {
+		return errors.New("runtime must not be negative")
+	}
+	date, err := time.Parse(time.DateOnly, releaseDate)
+	if err != nil {
+		return errors.New("release_date is required, use YYYY-MM-DD")
+	}
+	movie.ReleaseDate = date
+	return nil
+}
+
+// Run menyimpan setiap record lewat movieRepo dan menyusun laporan per baris.
+// Setiap movie disimpan di transaksinya sendiri, jadi baris yang gagal tidak membatalkan baris lain.
+func Run(ctx context.Context, movieRepo *repositories.MovieRepo, records []Record) models.ImportReport {
+	report := models.ImportReport{Rows: make([]models.ImportRowResult, 0, len(records))}
+	for _, record := range records {
+		result := models.ImportRowResult{
+			Row:    record.Row,
+			TMDBID: record.Movie.TMDBID,
+			Title:  record.Movie.Title,
+		}
+
+		err := record.Err
+		if err == nil {
+			var created bool
+			result.MovieID, created, err = movieRepo.UpsertImportedMovie(ctx, record.Movie)
+			if err == nil && created {
+				result.Status = models.ImportCreated
+				report.Created++
+			} else if err == nil {
+				result.Status = models.ImportUpdated
+				report.Updated++
+			}
+		}
+		if err != nil {
+			result.Status = models.ImportFailed
+			result.Error = err.Error()
+			report.Failed++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+	return report
+}
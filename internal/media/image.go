@@ -0,0 +1,129 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/storage"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+var (
+	ErrUnsupportedImage = errors.New("unsupported image type, use jpeg, png or webp")
+	ErrImageTooLarge    = errors.New("image is too large")
+)
+
+// maxPixels mencegah decompression bomb: file kecil dengan dimensi sangat besar
+const maxPixels = 40_000_000
+
+// extensions adalah tipe gambar yang diterima, dicek dari isi file bukan dari header upload
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// Variant adalah ukuran gambar yang disimpan. Width 0 berarti file asli disimpan apa adanya,
+// Square memotong bagian tengah gambar menjadi persegi sebelum di-resize.
+type Variant struct {
+	Name   string
+	Width  int
+	Square bool
+}
+
+var (
+	Thumbnail = Variant{Name: "thumbnail", Width: 185}
+	W500      = Variant{Name: "w500", Width: 500}
+	Original  = Variant{Name: "original"}
+	Avatar    = Variant{Name: "avatar", Width: 256, Square: true}
+)
+
+// DetectType mengembalikan MIME type dari isi file, ok false kalau bukan jpeg, png atau webp
+func DetectType(data []byte) (string, bool) {
+	mime := http.DetectContentType(data)
+	_, ok := extensions[mime]
+	return mime, ok
+}
+
+// SaveVariants memvalidasi gambar, membuat setiap variant lalu menyimpannya di dir dengan nama
+// berdasarkan hash isi file (<hash>_<variant>.<ext>), jadi upload gambar yang sama menghasilkan path yang sama.
+// Hasilnya adalah path publik per nama variant.
+func SaveVariants(ctx context.Context, store storage.Storage, dir string, data []byte, variants ...Variant) (map[string]string, error) {
+	mime, ok := DetectType(data)
+	if !ok {
+		return nil, ErrUnsupportedImage
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	var src image.Image
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])[:20]
+	paths := make(map[string]string, len(variants))
+	for _, variant := range variants {
+		out, ext := data, extensions[mime]
+		if variant.Width > 0 {
+			if src == nil {
+				if src, _, err = image.Decode(bytes.NewReader(data)); err != nil {
+					return nil, ErrUnsupportedImage
+				}
+			}
+			if out, ext, err = encode(resize(src, variant), mime); err != nil {
+				return nil, err
+			}
+		}
+
+		name := fmt.Sprintf("%s/%s_%s%s", dir, hash, variant.Name, ext)
+		if paths[variant.Name], err = store.Save(ctx, name, bytes.NewReader(out)); err != nil {
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// resize mengecilkan gambar ke lebar variant dengan rasio tetap (atau crop tengah kalau Square),
+// gambar yang lebih kecil tidak diperbesar
+func resize(src image.Image, variant Variant) image.Image {
+	bounds := src.Bounds()
+	if variant.Square {
+		side := min(bounds.Dx(), bounds.Dy())
+		x := bounds.Min.X + (bounds.Dx()-side)/2
+		y := bounds.Min.Y + (bounds.Dy()-side)/2
+		bounds = image.Rect(x, y, x+side, y+side)
+	}
+
+	width := min(variant.Width, bounds.Dx())
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+	return dst
+}
+
+// encode memakai png untuk sumber png (menjaga transparansi), selain itu jpeg
+func encode(img image.Image, mime string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if mime == "image/png" {
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), ".png", nil
+	}
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), ".jpg", nil
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+type AnalyticsGroup string
+
+const (
+	GroupByMovie    AnalyticsGroup = "movie"
+	GroupBySchedule AnalyticsGroup = "schedule"
+	GroupByCinema   AnalyticsGroup = "cinema"
+	GroupByLocation AnalyticsGroup = "location"
+)
+
+func (g AnalyticsGroup) IsValid() bool {
+	switch g {
+	case GroupByMovie, GroupBySchedule, GroupByCinema, GroupByLocation:
+		return true
+	}
+	return false
+}
+
+// AnalyticsBucket kosong berarti tanpa pembagian waktu
+type AnalyticsBucket string
+
+const (
+	BucketNone AnalyticsBucket = ""
+	BucketDay  AnalyticsBucket = "day"
+	BucketWeek AnalyticsBucket = "week"
+)
+
+func (b AnalyticsBucket) IsValid() bool {
+	switch b {
+	case BucketNone, BucketDay, BucketWeek:
+		return true
+	}
+	return false
+}
+
+// untuk admin, rentang tanggal berdasarkan tanggal tayang schedule
+type AnalyticsFilter struct {
+	DateFrom time.Time
+	DateTo   time.Time
+	GroupBy  AnalyticsGroup
+	Bucket   AnalyticsBucket
+}
+
+// SalesStat adalah penjualan satu grup (film / schedule / cinema / lokasi) dalam satu bucket waktu.
+// Revenue hanya harga tiket dari order paid dan used, service fee tidak dihitung.
+type SalesStat struct {
+	BucketStart *time.Time `json:"bucket_start,omitempty"`
+	GroupID     int        `json:"group_id,omitempty"`
+	GroupName   string     `json:"group_name,omitempty"`
+	Schedules   int        `json:"schedules"`
+	Revenue     int        `json:"revenue"`
+	TicketsSold int        `json:"tickets_sold"`
+	Capacity    int        `json:"capacity"`
+	Occupancy   float64    `json:"occupancy_percent"`
+}
+
+type SalesReport struct {
+	DateFrom string          `json:"date_from"`
+	DateTo   string          `json:"date_to"`
+	GroupBy  AnalyticsGroup  `json:"group_by"`
+	Bucket   AnalyticsBucket `json:"bucket,omitempty"`
+	Totals   SalesStat       `json:"totals"`
+	Rows     []SalesStat     `json:"rows"`
+}
+
+// SetOccupancy menghitung persentase kursi terjual dari kapasitas, dibulatkan 2 desimal
+func (s *SalesStat) SetOccupancy() {
+	if s.Capacity == 0 {
+		s.Occupancy = 0
+		return
+	}
+	s.Occupancy = math.Round(float64(s.TicketsSold)*10000/float64(s.Capacity)) / 100
+}
+
+// SumSales menjumlahkan semua baris. Setiap schedule hanya masuk ke satu grup per bucket,
+// jadi totalnya cukup dijumlahkan.
+func SumSales(rows []SalesStat) SalesStat {
+	var total SalesStat
+	for _, row := range rows {
+		total.Schedules += row.Schedules
+		total.Revenue += row.Revenue
+		total.TicketsSold += row.TicketsSold
+		total.Capacity += row.Capacity
+	}
+	total.SetOccupancy()
+	return total
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type SeatHold struct {
+	ID         int       `db:"id" json:"id"`
+	UserID     int       `db:"users_id" json:"user_id"`
+	ScheduleID int       `db:"schedules_id" json:"schedule_id"`
+	ExpiresAt  time.Time `db:"expires_at" json:"expires_at"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	Seats      []Seat    `db:"-" json:"seats"`
+}
+
+type CreateHoldRequest struct {
+	ScheduleID int   `json:"schedule_id" binding:"required" example:"1"`
+	SeatIDs    []int `json:"seat_ids" binding:"required,min=1" swaggertype:"array,integer" example:"1"`
+}
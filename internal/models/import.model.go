@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// MovieImport adalah satu film hasil parsing file import TMDB, genre dan cast berupa nama
+type MovieImport struct {
+	TMDBID      int
+	Title       string
+	Overview    string
+	Poster      string
+	Backdrop    string
+	Popularity  float64
+	ReleaseDate time.Time
+	Duration    int
+	Director    string
+	Genres      []string
+	Casts       []string
+}
+
+type ImportStatus string
+
+const (
+	ImportCreated ImportStatus = "created"
+	ImportUpdated ImportStatus = "updated"
+	ImportFailed  ImportStatus = "failed"
+)
+
+// ImportRowResult adalah hasil satu baris import, Row dimulai dari 1 (untuk csv tidak termasuk header)
+type ImportRowResult struct {
+	Row     int          `json:"row"`
+	TMDBID  int          `json:"tmdb_id,omitempty"`
+	Title   string       `json:"title,omitempty"`
+	Status  ImportStatus `json:"status"`
+	MovieID int          `json:"movie_id,omitempty"`
+	Error   string       `json:"error,omitempty"`
+}
+
+type ImportReport struct {
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
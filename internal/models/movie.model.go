@@ -34,6 +34,16 @@ type Genre struct {
 	Name string `db:"name" json:"name"`
 }
 
+// untuk admin
+type GenreRequest struct {
+	Name string `json:"name" binding:"required" example:"Action"`
+}
+
+// untuk admin
+type CastRequest struct {
+	Name string `json:"name" binding:"required" example:"Robert Downey Jr."`
+}
+
 type MovieGenre struct {
 	ID      int `db:"id" json:"id"`
 	MovieID int `db:"movies_id" json:"movie_id"`
@@ -50,4 +60,20 @@ type UpdateMovieRequest struct {
 	Duration    int       `json:"duration" binding:"required" example:"180"`
 	Director    string    `json:"director" binding:"required" example:"Anthony Russo, Joe Russo"`
 	Popularity  float64   `json:"popularity" binding:"required" example:"95.6"`
+	// kalau dikirim, genre dan cast movie diganti dengan daftar ini (array kosong = hapus semua)
+	GenreIDs *[]int `json:"genre_ids" swaggertype:"array,integer" example:"1,2"`
+	CastIDs  *[]int `json:"cast_ids" swaggertype:"array,integer" example:"1,2"`
+}
+
+type CreateMovieRequest struct {
+	Title       string    `json:"title" binding:"required" example:"Avengers: Endgame"`
+	Poster      string    `json:"poster" binding:"required" example:"https://image.tmdb.org/t/p/w500/poster.jpg"`
+	Backdrop    string    `json:"backdrop" binding:"required" example:"https://image.tmdb.org/t/p/w500/backdrop.jpg"`
+	Overview    string    `json:"overview" binding:"required" example:"After the devastating events of Infinity War..."`
+	ReleaseDate time.Time `json:"release_date" binding:"required" example:"2019-04-26T00:00:00Z"`
+	Duration    int       `json:"duration" binding:"required" example:"180"`
+	Director    string    `json:"director" binding:"required" example:"Anthony Russo, Joe Russo"`
+	Popularity  float64   `json:"popularity" example:"95.6"`
+	GenreIDs    []int     `json:"genre_ids" binding:"required,min=1" swaggertype:"array,integer" example:"1,2"`
+	CastIDs     []int     `json:"cast_ids" swaggertype:"array,integer" example:"1,2"`
 }
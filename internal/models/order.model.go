@@ -2,18 +2,65 @@ package models
 
 import "time"
 
+type OrderStatus string
+
+const (
+	OrderPending   OrderStatus = "pending"
+	OrderPaid      OrderStatus = "paid"
+	OrderUsed      OrderStatus = "used"
+	OrderExpired   OrderStatus = "expired"
+	OrderCancelled OrderStatus = "cancelled"
+	OrderRefunded  OrderStatus = "refunded"
+)
+
+// orderTransitions berisi perpindahan status yang diizinkan:
+// pending -> paid -> used, pending -> expired / cancelled, paid -> cancelled / refunded
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderPending: {OrderPaid, OrderExpired, OrderCancelled},
+	OrderPaid:    {OrderUsed, OrderCancelled, OrderRefunded},
+}
+
+func (s OrderStatus) IsValid() bool {
+	switch s {
+	case OrderPending, OrderPaid, OrderUsed, OrderExpired, OrderCancelled, OrderRefunded:
+		return true
+	}
+	return false
+}
+
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
 type Order struct {
-	ID         int        `db:"id" json:"id"`
-	QRCode     string     `db:"qr_code" json:"qr_code"`
-	UserID     int        `db:"users_id" json:"user_id" example:"1"`
-	ScheduleID int        `db:"schedules_id" json:"schedule_id" example:"10"`
-	PaymentID  int        `db:"payments_id" json:"payment_id" example:"2"`
-	FullName   string     `db:"fullname" json:"fullname" example:"farid rd"`
-	Email      string     `db:"email" json:"email" example:"darari@mail.com"`
-	Phone      string     `db:"phone_number" json:"phone" example:"08123456789"`
-	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
-	UpdatedAt  *time.Time `db:"updated_at" json:"updated_at"`
-	Seats      []Seat     `db:"-" json:"seats"`
+	ID           int         `db:"id" json:"id"`
+	QRCode       string      `db:"qr_code" json:"qr_code"`
+	UserID       int         `db:"users_id" json:"user_id" example:"1"`
+	ScheduleID   int         `db:"schedules_id" json:"schedule_id" example:"10"`
+	PaymentID    int         `db:"payments_id" json:"payment_id" example:"2"`
+	FullName     string      `db:"fullname" json:"fullname" example:"farid rd"`
+	Email        string      `db:"email" json:"email" example:"darari@mail.com"`
+	Phone        string      `db:"phone_number" json:"phone" example:"08123456789"`
+	ServiceFee   int         `db:"service_fee" json:"service_fee" example:"4000"`
+	TotalPrice   int         `db:"total_price" json:"total_price" example:"104000"`
+	Status       OrderStatus `db:"status" json:"status"`
+	ExpiresAt    *time.Time  `db:"expires_at" json:"expires_at,omitempty"`
+	PaidAt       *time.Time  `db:"paid_at" json:"paid_at,omitempty"`
+	UsedAt       *time.Time  `db:"used_at" json:"used_at,omitempty"`
+	ExpiredAt    *time.Time  `db:"expired_at" json:"expired_at,omitempty"`
+	CancelledAt  *time.Time  `db:"cancelled_at" json:"cancelled_at,omitempty"`
+	RefundedAt   *time.Time  `db:"refunded_at" json:"refunded_at,omitempty"`
+	CancelReason *string     `db:"cancel_reason" json:"cancel_reason,omitempty"`
+	CancelledBy  *int        `db:"cancelled_by" json:"cancelled_by,omitempty"`
+	CreatedAt    time.Time   `db:"created_at" json:"created_at"`
+	UpdatedAt    *time.Time  `db:"updated_at" json:"updated_at"`
+	Seats        []Seat      `db:"-" json:"seats"`
+	Prices       []SeatPrice `db:"-" json:"price_breakdown,omitempty"`
 }
 
 type OrderSeat struct {
@@ -21,29 +68,225 @@ type OrderSeat struct {
 	SeatID  int `db:"seats_id" json:"seat_id"`
 }
 
+type SeatCategory string
+
+const (
+	SeatRegular  SeatCategory = "regular"
+	SeatVIP      SeatCategory = "vip"
+	SeatLoveNest SeatCategory = "love_nest"
+)
+
+func (c SeatCategory) IsValid() bool {
+	switch c {
+	case SeatRegular, SeatVIP, SeatLoveNest:
+		return true
+	}
+	return false
+}
+
+type SeatStatus string
+
+const (
+	SeatAvailable SeatStatus = "available"
+	SeatHeld      SeatStatus = "held"
+	SeatSold      SeatStatus = "sold"
+	SeatBlocked   SeatStatus = "blocked"
+)
+
 type Seat struct {
-	ID       int    `db:"id" json:"id"`
-	SeatCode string `db:"seat_code" json:"seat_code"`
+	ID       int          `db:"id" json:"id"`
+	SeatCode string       `db:"seat_code" json:"seat_code"`
+	Row      string       `db:"row_code" json:"row,omitempty"`
+	Column   int          `db:"column_number" json:"column,omitempty"`
+	Category SeatCategory `db:"category" json:"category,omitempty"`
+	Blocked  bool         `db:"is_blocked" json:"blocked,omitempty"`
+	Status   SeatStatus   `db:"-" json:"status,omitempty"`
+}
+
+type SeatRow struct {
+	Row   string `json:"row"`
+	Seats []Seat `json:"seats"`
+}
+
+type SeatMap struct {
+	ScheduleID int       `json:"schedule_id"`
+	CinemaID   int       `json:"cinema_id"`
+	Rows       []SeatRow `json:"rows"`
+}
+
+// untuk admin
+type SeatLayoutItem struct {
+	Row      string       `json:"row" binding:"required" example:"A"`
+	Column   int          `json:"column" binding:"required,min=1" example:"1"`
+	Category SeatCategory `json:"category" example:"regular"`
+	Blocked  bool         `json:"blocked" example:"false"`
+}
+
+// untuk admin
+type SeatLayoutRequest struct {
+	Seats []SeatLayoutItem `json:"seats" binding:"required,min=1,dive"`
+}
+
+type CheckInReason string
+
+const (
+	CheckInAccepted       CheckInReason = "accepted"
+	CheckInInvalidTicket  CheckInReason = "invalid_ticket"
+	CheckInTicketExpired  CheckInReason = "ticket_expired"
+	CheckInOrderNotFound  CheckInReason = "order_not_found"
+	CheckInTicketMismatch CheckInReason = "ticket_mismatch"
+	CheckInNotPaid        CheckInReason = "order_not_paid"
+	CheckInAlreadyUsed    CheckInReason = "already_checked_in"
+	CheckInNotToday       CheckInReason = "not_today"
+)
+
+// untuk staff
+type CheckInRequest struct {
+	Token string `json:"token" binding:"required" example:"eyJvaWQiOjF9.c2lnbmF0dXJl"`
+}
+
+type CheckInResult struct {
+	Accepted   bool          `json:"accepted"`
+	Reason     CheckInReason `json:"reason"`
+	OrderID    int           `json:"order_id,omitempty"`
+	ScheduleID int           `json:"schedule_id,omitempty"`
+	MovieTitle string        `json:"movie_title,omitempty"`
+	ShowDate   string        `json:"show_date,omitempty"`
+	ShowTime   string        `json:"show_time,omitempty"`
+	Seats      []string      `json:"seats,omitempty"`
+	AdmittedAt *time.Time    `json:"admitted_at,omitempty"`
+}
+
+// CancellationPolicy menentukan berapa persen pembayaran yang dikembalikan saat order paid dibatalkan,
+// dihitung dari sisa waktu sebelum jam tayang
+type CancellationPolicy struct {
+	FullRefundBefore     time.Duration
+	PartialRefundBefore  time.Duration
+	PartialRefundPercent int
+}
+
+// RefundPercent mengembalikan persentase refund, ok false berarti pembatalan sudah ditutup
+func (p CancellationPolicy) RefundPercent(untilShow time.Duration) (percent int, ok bool) {
+	switch {
+	case untilShow >= p.FullRefundBefore:
+		return 100, true
+	case untilShow >= p.PartialRefundBefore:
+		return p.PartialRefundPercent, true
+	}
+	return 0, false
+}
+
+type CancelOrderRequest struct {
+	Reason string `json:"reason" example:"tidak jadi nonton"`
+}
+
+// untuk admin
+type ForceCancelOrderRequest struct {
+	Reason string `json:"reason" binding:"required" example:"studio maintenance"`
+}
+
+type CancelOrderResult struct {
+	Order  *Order  `json:"order"`
+	Refund *Refund `json:"refund"`
+}
+
+// untuk admin
+type UpdateOrderStatusRequest struct {
+	Status OrderStatus `json:"status" binding:"required" example:"paid"`
+}
+
+// untuk admin, nilai 0 / kosong berarti filter tidak dipakai
+type AdminOrderFilter struct {
+	DateFrom   *time.Time
+	DateTo     *time.Time
+	MovieID    int
+	CinemaID   int
+	LocationID int
+	PaymentID  int
+	Status     OrderStatus
+	Email      string
+	Sort       string
+	Page       int
+	PageSize   int
+}
+
+// AdminOrderSummary adalah satu baris pada daftar order admin
+type AdminOrderSummary struct {
+	ID            int         `json:"id"`
+	UserID        int         `json:"user_id"`
+	UserEmail     string      `json:"user_email"`
+	FullName      string      `json:"fullname"`
+	Status        OrderStatus `json:"status"`
+	TotalPrice    int         `json:"total_price"`
+	MovieID       int         `json:"movie_id"`
+	MovieTitle    string      `json:"movie_title"`
+	CinemaName    string      `json:"cinema_name"`
+	Location      string      `json:"location"`
+	ShowDate      time.Time   `json:"show_date"`
+	ShowTime      string      `json:"show_time"`
+	PaymentMethod string      `json:"payment_method"`
+	SeatCodes     []string    `json:"seat_codes"`
+	CreatedAt     time.Time   `json:"created_at"`
+}
+
+// SeatManifestRow adalah satu kursi pada manifest schedule, kolom order kosong kalau kursi belum terjual
+type SeatManifestRow struct {
+	SeatCode    string
+	Row         string
+	Column      int
+	Category    SeatCategory
+	Status      SeatStatus
+	OrderID     *int
+	OrderStatus *OrderStatus
+	FullName    *string
+	Email       *string
+	Phone       *string
+	Price       *int
+	AdmittedAt  *time.Time
+}
+
+type OrderMovie struct {
+	ID       int    `json:"id"`
+	Title    string `json:"title"`
+	Poster   string `json:"poster"`
+	Duration int    `json:"duration"`
+}
+
+// AdminOrderDetail adalah order lengkap dengan schedule, film, pembayaran dan refund
+type AdminOrderDetail struct {
+	Order
+	UserEmail     string          `json:"user_email"`
+	Movie         OrderMovie      `json:"movie"`
+	Schedule      ScheduleDetail  `json:"schedule"`
+	PaymentMethod PaymentMethod   `json:"payment_method"`
+	Payments      []PaymentIntent `json:"payments"`
+	Refunds       []Refund        `json:"refunds"`
+}
+
+// OrderContactRequest adalah bagian order yang boleh diisi client saat checkout.
+// Harga, status dan qr_code selalu dihitung di server.
+type OrderContactRequest struct {
+	ScheduleID int    `json:"schedule_id" example:"1"`
+	PaymentID  int    `json:"payment_id" example:"1"`
+	FullName   string `json:"fullname" example:"Farid RD"`
+	Email      string `json:"email" example:"farid@mail.com"`
+	Phone      string `json:"phone" example:"08123456789"`
 }
 
-type PaymentMethod struct {
-	ID   int    `db:"id" json:"id"`
-	Name string `db:"name" json:"name"`
+// NewOrder membuat order baru milik userID dari data checkout
+func (r OrderContactRequest) NewOrder(userID int) *Order {
+	return &Order{
+		UserID:     userID,
+		ScheduleID: r.ScheduleID,
+		PaymentID:  r.PaymentID,
+		FullName:   r.FullName,
+		Email:      r.Email,
+		Phone:      r.Phone,
+	}
 }
 
 type CreateOrderRequest struct {
-	Order   Order `json:"order"`
-	SeatIDs []int `json:"seat_ids" `
-}
-
-type CreateOrderExample struct {
-	Order struct {
-		UserID     int    `json:"user_id" example:"1"`
-		ScheduleID int    `json:"schedule_id" example:"1"`
-		PaymentID  int    `json:"payment_id" example:"1"`
-		FullName   string `json:"fullname" example:"Farid RD"`
-		Email      string `json:"email" example:"farid@mail.com"`
-		Phone      string `json:"phone" example:"08123456789"`
-	} `json:"order"`
-	SeatIDs []int `json:"seat_ids" swaggertype:"array,integer" example:"1"`
+	Order   OrderContactRequest `json:"order"`
+	HoldID  int                 `json:"hold_id" binding:"required" example:"1"`
+	SeatIDs []int               `json:"seat_ids" swaggertype:"array,integer" example:"1"`
 }
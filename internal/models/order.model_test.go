@@ -0,0 +1,105 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOrderStatusCanTransitionTo(t *testing.T) {
+	statuses := []OrderStatus{OrderPending, OrderPaid, OrderUsed, OrderExpired, OrderCancelled, OrderRefunded}
+	allowed := map[[2]OrderStatus]bool{
+		{OrderPending, OrderPaid}:      true,
+		{OrderPending, OrderExpired}:   true,
+		{OrderPending, OrderCancelled}: true,
+		{OrderPaid, OrderUsed}:         true,
+		{OrderPaid, OrderCancelled}:    true,
+		{OrderPaid, OrderRefunded}:     true,
+	}
+
+	// seluruh kombinasi dicek, status akhir (used, expired, cancelled, refunded) tidak boleh pindah lagi
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := allowed[[2]OrderStatus{from, to}]
+			if got := from.CanTransitionTo(to); got != want {
+				t.Errorf("%s -> %s = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestOrderStatusUnknown(t *testing.T) {
+	tests := []struct {
+		from OrderStatus
+		to   OrderStatus
+	}{
+		{from: "", to: OrderPaid},
+		{from: "draft", to: OrderPaid},
+		{from: OrderPending, to: "draft"},
+		{from: OrderPaid, to: ""},
+	}
+	for _, tt := range tests {
+		if tt.from.CanTransitionTo(tt.to) {
+			t.Errorf("%q -> %q should not be allowed", tt.from, tt.to)
+		}
+	}
+}
+
+func TestOrderStatusIsValid(t *testing.T) {
+	tests := []struct {
+		status OrderStatus
+		want   bool
+	}{
+		{status: OrderPending, want: true},
+		{status: OrderPaid, want: true},
+		{status: OrderUsed, want: true},
+		{status: OrderExpired, want: true},
+		{status: OrderCancelled, want: true},
+		{status: OrderRefunded, want: true},
+		{status: "", want: false},
+		{status: "PAID", want: false},
+	}
+	for _, tt := range tests {
+		if got := tt.status.IsValid(); got != tt.want {
+			t.Errorf("IsValid(%q) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestCancellationPolicyRefundPercent(t *testing.T) {
+	policy := CancellationPolicy{
+		FullRefundBefore:     24 * time.Hour,
+		PartialRefundBefore:  3 * time.Hour,
+		PartialRefundPercent: 50,
+	}
+
+	tests := []struct {
+		name        string
+		policy      CancellationPolicy
+		untilShow   time.Duration
+		wantPercent int
+		wantOK      bool
+	}{
+		{name: "jauh sebelum tayang", policy: policy, untilShow: 72 * time.Hour, wantPercent: 100, wantOK: true},
+		{name: "tepat batas refund penuh", policy: policy, untilShow: 24 * time.Hour, wantPercent: 100, wantOK: true},
+		{name: "sedikit lewat batas refund penuh", policy: policy, untilShow: 24*time.Hour - time.Nanosecond, wantPercent: 50, wantOK: true},
+		{name: "tepat batas refund sebagian", policy: policy, untilShow: 3 * time.Hour, wantPercent: 50, wantOK: true},
+		{name: "sedikit lewat batas refund sebagian", policy: policy, untilShow: 3*time.Hour - time.Nanosecond, wantOK: false},
+		{name: "saat tayang", policy: policy, untilShow: 0, wantOK: false},
+		{name: "sudah lewat jam tayang", policy: policy, untilShow: -time.Hour, wantOK: false},
+		{
+			name:        "refund sebagian 0 persen",
+			policy:      CancellationPolicy{FullRefundBefore: 24 * time.Hour, PartialRefundBefore: time.Hour},
+			untilShow:   2 * time.Hour,
+			wantPercent: 0,
+			wantOK:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			percent, ok := tt.policy.RefundPercent(tt.untilShow)
+			if percent != tt.wantPercent || ok != tt.wantOK {
+				t.Fatalf("RefundPercent(%s) = (%d, %v), want (%d, %v)", tt.untilShow, percent, ok, tt.wantPercent, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+type PaymentMethod struct {
+	ID           int     `db:"id" json:"id"`
+	Name         string  `db:"name" json:"name"`
+	LogoPath     *string `db:"logo_path" json:"logo_path"`
+	DisplayOrder int     `db:"display_order" json:"display_order"`
+	IsActive     bool    `db:"is_active" json:"is_active"`
+}
+
+// untuk admin
+type PaymentMethodRequest struct {
+	Name         string  `json:"name" binding:"required" example:"BCA"`
+	LogoPath     *string `json:"logo_path" example:"/img/payments/bca.png"`
+	DisplayOrder int     `json:"display_order" example:"1"`
+	IsActive     *bool   `json:"is_active" binding:"required" example:"true"`
+}
+
+type PaymentIntent struct {
+	ID          int        `db:"id" json:"id"`
+	OrderID     int        `db:"orders_id" json:"order_id"`
+	Provider    string     `db:"provider" json:"provider"`
+	ChargeID    string     `db:"charge_id" json:"charge_id"`
+	Amount      int        `db:"amount" json:"amount"`
+	Status      string     `db:"status" json:"status"`
+	CheckoutURL string     `db:"checkout_url" json:"checkout_url"`
+	CreatedAt   time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt   *time.Time `db:"updated_at" json:"updated_at"`
+}
+
+type RefundStatus string
+
+const (
+	RefundPending   RefundStatus = "pending"
+	RefundSucceeded RefundStatus = "succeeded"
+	RefundFailed    RefundStatus = "failed"
+)
+
+// Refund dicatat setiap order paid dibatalkan, PaymentIntentID nil untuk order tanpa pembayaran online
+type Refund struct {
+	ID              int          `db:"id" json:"id"`
+	OrderID         int          `db:"orders_id" json:"order_id"`
+	PaymentIntentID *int         `db:"payment_intents_id" json:"payment_intent_id"`
+	ChargeID        string       `db:"-" json:"-"`
+	Amount          int          `db:"amount" json:"amount"`
+	Percent         int          `db:"percent" json:"percent"`
+	Reason          string       `db:"reason" json:"reason"`
+	Forced          bool         `db:"is_forced" json:"forced"`
+	Status          RefundStatus `db:"status" json:"status"`
+	RequestedBy     *int         `db:"requested_by" json:"requested_by"`
+	CreatedAt       time.Time    `db:"created_at" json:"created_at"`
+	UpdatedAt       *time.Time   `db:"updated_at" json:"updated_at"`
+}
+
+// untuk simulasi pembayaran dengan fake provider
+type FakePaymentRequest struct {
+	Status string `json:"status" binding:"required" example:"succeeded"`
+}
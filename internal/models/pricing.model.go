@@ -0,0 +1,90 @@
+package models
+
+// SeatPrice adalah rincian harga satu kursi pada sebuah order
+type SeatPrice struct {
+	SeatID            int          `db:"seats_id" json:"seat_id"`
+	SeatCode          string       `db:"seat_code" json:"seat_code"`
+	Category          SeatCategory `db:"category" json:"category"`
+	BasePrice         int          `db:"base_price" json:"base_price"`
+	CategorySurcharge int          `db:"category_surcharge" json:"category_surcharge"`
+	RuleAdjustment    int          `db:"rule_adjustment" json:"rule_adjustment"`
+	Price             int          `db:"price" json:"price"`
+}
+
+// PriceAdjustment adalah biaya tambahan atau potongan di level order
+type PriceAdjustment struct {
+	Name   string `json:"name" example:"service_fee"`
+	Amount int    `json:"amount" example:"4000"`
+}
+
+// OrderQuote adalah rincian harga order. Subtotal adalah harga kursi sebelum potongan,
+// Total adalah yang harus dibayar customer (harga kursi setelah potongan + fees).
+type OrderQuote struct {
+	ScheduleID int               `json:"schedule_id"`
+	Items      []SeatPrice       `json:"items"`
+	Subtotal   int               `json:"subtotal"`
+	Fees       []PriceAdjustment `json:"fees"`
+	Discounts  []PriceAdjustment `json:"discounts"`
+	Total      int               `json:"total"`
+}
+
+// PriceSeats menghitung harga tiap kursi: basePrice + CategorySurcharge kursi + pricing rules.
+// Rule dengan amount positif ditambahkan lebih dulu, rule negatif dicatat sebagai discount
+// dan tidak pernah membuat harga kursi di bawah 0. Fees belum dihitung, Total hanya total harga kursi.
+func PriceSeats(basePrice int, seats []SeatPrice, rules []PriceAdjustment) OrderQuote {
+	quote := OrderQuote{
+		Items:     make([]SeatPrice, 0, len(seats)),
+		Fees:      []PriceAdjustment{},
+		Discounts: []PriceAdjustment{},
+	}
+	discounts := make([]int, len(rules))
+	for _, p := range seats {
+		p.BasePrice = basePrice
+		gross := p.BasePrice + p.CategorySurcharge
+		for _, rule := range rules {
+			if rule.Amount > 0 {
+				gross += rule.Amount
+			}
+		}
+		net := gross
+		for i, rule := range rules {
+			if rule.Amount < 0 {
+				cut := min(-rule.Amount, net)
+				net -= cut
+				discounts[i] += cut
+			}
+		}
+
+		p.Price = net
+		p.RuleAdjustment = net - p.BasePrice - p.CategorySurcharge
+		quote.Subtotal += gross
+		quote.Total += net
+		quote.Items = append(quote.Items, p)
+	}
+
+	for i, rule := range rules {
+		if discounts[i] > 0 {
+			quote.Discounts = append(quote.Discounts, PriceAdjustment{Name: rule.Name, Amount: discounts[i]})
+		}
+	}
+	return quote
+}
+
+// AddServiceFee menambahkan service fee per tiket ke quote
+func (q *OrderQuote) AddServiceFee(feePerTicket int) {
+	if feePerTicket <= 0 {
+		return
+	}
+	fee := feePerTicket * len(q.Items)
+	q.Fees = append(q.Fees, PriceAdjustment{Name: "service_fee", Amount: fee})
+	q.Total += fee
+}
+
+// FeeTotal adalah jumlah seluruh fees pada quote
+func (q *OrderQuote) FeeTotal() int {
+	total := 0
+	for _, fee := range q.Fees {
+		total += fee.Amount
+	}
+	return total
+}
@@ -0,0 +1,209 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPriceSeats(t *testing.T) {
+	regular := SeatPrice{SeatID: 1, SeatCode: "A1", Category: "regular"}
+	vip := SeatPrice{SeatID: 2, SeatCode: "A2", Category: "vip", CategorySurcharge: 15000}
+
+	tests := []struct {
+		name          string
+		basePrice     int
+		seats         []SeatPrice
+		rules         []PriceAdjustment
+		wantPrices    []int
+		wantAdjusts   []int
+		wantSubtotal  int
+		wantTotal     int
+		wantDiscounts []PriceAdjustment
+	}{
+		{
+			name:          "harga dasar saja",
+			basePrice:     40000,
+			seats:         []SeatPrice{regular, regular},
+			wantPrices:    []int{40000, 40000},
+			wantAdjusts:   []int{0, 0},
+			wantSubtotal:  80000,
+			wantTotal:     80000,
+			wantDiscounts: []PriceAdjustment{},
+		},
+		{
+			name:          "surcharge kategori kursi",
+			basePrice:     40000,
+			seats:         []SeatPrice{regular, vip},
+			wantPrices:    []int{40000, 55000},
+			wantAdjusts:   []int{0, 0},
+			wantSubtotal:  95000,
+			wantTotal:     95000,
+			wantDiscounts: []PriceAdjustment{},
+		},
+		{
+			name:          "rule hari / jam menambah harga",
+			basePrice:     40000,
+			seats:         []SeatPrice{vip},
+			rules:         []PriceAdjustment{{Name: "weekend", Amount: 10000}},
+			wantPrices:    []int{65000},
+			wantAdjusts:   []int{10000},
+			wantSubtotal:  65000,
+			wantTotal:     65000,
+			wantDiscounts: []PriceAdjustment{},
+		},
+		{
+			name:          "discount dicatat per rule",
+			basePrice:     40000,
+			seats:         []SeatPrice{regular, regular},
+			rules:         []PriceAdjustment{{Name: "matinee", Amount: -5000}},
+			wantPrices:    []int{35000, 35000},
+			wantAdjusts:   []int{-5000, -5000},
+			wantSubtotal:  80000,
+			wantTotal:     70000,
+			wantDiscounts: []PriceAdjustment{{Name: "matinee", Amount: 10000}},
+		},
+		{
+			name:          "rule positif dihitung sebelum discount",
+			basePrice:     40000,
+			seats:         []SeatPrice{vip},
+			rules:         []PriceAdjustment{{Name: "matinee", Amount: -20000}, {Name: "weekend", Amount: 10000}},
+			wantPrices:    []int{45000},
+			wantAdjusts:   []int{-10000},
+			wantSubtotal:  65000,
+			wantTotal:     45000,
+			wantDiscounts: []PriceAdjustment{{Name: "matinee", Amount: 20000}},
+		},
+		{
+			name:          "discount tidak membuat harga di bawah 0",
+			basePrice:     10000,
+			seats:         []SeatPrice{regular},
+			rules:         []PriceAdjustment{{Name: "promo", Amount: -15000}},
+			wantPrices:    []int{0},
+			wantAdjusts:   []int{-10000},
+			wantSubtotal:  10000,
+			wantTotal:     0,
+			wantDiscounts: []PriceAdjustment{{Name: "promo", Amount: 10000}},
+		},
+		{
+			name:      "discount berikutnya hanya memotong sisa harga",
+			basePrice: 10000,
+			seats:     []SeatPrice{regular},
+			rules: []PriceAdjustment{
+				{Name: "promo", Amount: -6000},
+				{Name: "member", Amount: -6000},
+				{Name: "voucher", Amount: -1000},
+			},
+			wantPrices:    []int{0},
+			wantAdjusts:   []int{-10000},
+			wantSubtotal:  10000,
+			wantTotal:     0,
+			wantDiscounts: []PriceAdjustment{{Name: "promo", Amount: 6000}, {Name: "member", Amount: 4000}},
+		},
+		{
+			name:          "tanpa kursi",
+			basePrice:     40000,
+			wantPrices:    []int{},
+			wantAdjusts:   []int{},
+			wantDiscounts: []PriceAdjustment{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := PriceSeats(tt.basePrice, tt.seats, tt.rules)
+
+			prices := []int{}
+			adjusts := []int{}
+			for i, item := range quote.Items {
+				if item.BasePrice != tt.basePrice || item.SeatID != tt.seats[i].SeatID {
+					t.Fatalf("item %d = %+v, want base price %d and seat %d", i, item, tt.basePrice, tt.seats[i].SeatID)
+				}
+				if item.BasePrice+item.CategorySurcharge+item.RuleAdjustment != item.Price {
+					t.Fatalf("item %d breakdown does not add up: %+v", i, item)
+				}
+				prices = append(prices, item.Price)
+				adjusts = append(adjusts, item.RuleAdjustment)
+			}
+			if !reflect.DeepEqual(prices, tt.wantPrices) {
+				t.Errorf("prices = %v, want %v", prices, tt.wantPrices)
+			}
+			if !reflect.DeepEqual(adjusts, tt.wantAdjusts) {
+				t.Errorf("rule adjustments = %v, want %v", adjusts, tt.wantAdjusts)
+			}
+			if quote.Subtotal != tt.wantSubtotal || quote.Total != tt.wantTotal {
+				t.Errorf("subtotal, total = %d, %d, want %d, %d", quote.Subtotal, quote.Total, tt.wantSubtotal, tt.wantTotal)
+			}
+			if !reflect.DeepEqual(quote.Discounts, tt.wantDiscounts) {
+				t.Errorf("discounts = %+v, want %+v", quote.Discounts, tt.wantDiscounts)
+			}
+			if len(quote.Fees) != 0 {
+				t.Errorf("fees = %+v, want none", quote.Fees)
+			}
+		})
+	}
+}
+
+func TestOrderQuoteFees(t *testing.T) {
+	items := []SeatPrice{{SeatID: 1, Price: 40000}, {SeatID: 2, Price: 40000}}
+
+	tests := []struct {
+		name         string
+		items        []SeatPrice
+		fees         []PriceAdjustment
+		total        int
+		feePerTicket int
+		wantFees     []PriceAdjustment
+		wantFeeTotal int
+		wantTotal    int
+	}{
+		{
+			name:         "service fee per tiket",
+			items:        items,
+			total:        80000,
+			feePerTicket: 2500,
+			wantFees:     []PriceAdjustment{{Name: "service_fee", Amount: 5000}},
+			wantFeeTotal: 5000,
+			wantTotal:    85000,
+		},
+		{
+			name:         "service fee nol tidak dicatat",
+			items:        items,
+			total:        80000,
+			feePerTicket: 0,
+			wantFeeTotal: 0,
+			wantTotal:    80000,
+		},
+		{
+			name:         "fee lain sudah ada",
+			items:        items,
+			fees:         []PriceAdjustment{{Name: "convenience", Amount: 1000}},
+			total:        81000,
+			feePerTicket: 2500,
+			wantFees:     []PriceAdjustment{{Name: "convenience", Amount: 1000}, {Name: "service_fee", Amount: 5000}},
+			wantFeeTotal: 6000,
+			wantTotal:    86000,
+		},
+		{
+			name:         "tanpa kursi",
+			feePerTicket: 2500,
+			wantFees:     []PriceAdjustment{{Name: "service_fee", Amount: 0}},
+			wantFeeTotal: 0,
+			wantTotal:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := OrderQuote{Items: tt.items, Fees: tt.fees, Total: tt.total}
+			quote.AddServiceFee(tt.feePerTicket)
+
+			if !reflect.DeepEqual(quote.Fees, tt.wantFees) {
+				t.Errorf("fees = %+v, want %+v", quote.Fees, tt.wantFees)
+			}
+			if got := quote.FeeTotal(); got != tt.wantFeeTotal {
+				t.Errorf("FeeTotal() = %d, want %d", got, tt.wantFeeTotal)
+			}
+			if quote.Total != tt.wantTotal {
+				t.Errorf("total = %d, want %d", quote.Total, tt.wantTotal)
+			}
+		})
+	}
+}
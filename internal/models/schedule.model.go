@@ -9,11 +9,32 @@ type Schedule struct {
 	TimeID     int       `db:"times_id" json:"time_id"`
 	LocationID int       `db:"locations_id" json:"location_id"`
 	Date       time.Time `db:"date" json:"date"`
+	Price      int       `db:"price" json:"price"`
+}
+
+// ScheduleDetail adalah schedule dengan data cinema, lokasi dan jam tayang yang sudah di-join
+type ScheduleDetail struct {
+	ID       int       `json:"id"`
+	MovieID  int       `json:"movie_id"`
+	Date     time.Time `json:"date"`
+	Cinema   Cinema    `json:"cinema"`
+	Location Location  `json:"location"`
+	Time     Time      `json:"time"`
+	Price    int       `json:"price"`
+}
+
+type ScheduleFilter struct {
+	Date       *time.Time
+	LocationID int
+	CinemaID   int
+	TimeFrom   string
+	TimeTo     string
 }
 
 type Cinema struct {
-	ID   int    `db:"id" json:"id"`
-	Name string `db:"name" json:"name"`
+	ID        int    `db:"id" json:"id"`
+	Name      string `db:"name" json:"name"`
+	BasePrice int    `db:"base_price" json:"base_price"`
 }
 
 type Location struct {
@@ -25,3 +46,29 @@ type Time struct {
 	ID   int    `db:"id" json:"id"`
 	Time string `db:"time" json:"time"`
 }
+
+// untuk admin
+type CinemaRequest struct {
+	Name      string `json:"name" binding:"required" example:"ebv.id"`
+	BasePrice int    `json:"base_price" binding:"min=0" example:"45000"`
+}
+
+// untuk admin
+type LocationRequest struct {
+	Location string `json:"location" binding:"required" example:"Purwokerto"`
+}
+
+// untuk admin
+type TimeRequest struct {
+	Time string `json:"time" binding:"required" example:"13:00"`
+}
+
+// untuk admin
+type ScheduleRequest struct {
+	MovieID    int    `json:"movie_id" binding:"required" example:"1"`
+	CinemaID   int    `json:"cinema_id" binding:"required" example:"1"`
+	LocationID int    `json:"location_id" binding:"required" example:"1"`
+	TimeID     int    `json:"time_id" binding:"required" example:"1"`
+	Date       string `json:"date" binding:"required" example:"2025-10-20"`
+	Price      int    `json:"price" binding:"min=0" example:"50000"`
+}
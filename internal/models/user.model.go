@@ -7,16 +7,26 @@ type Role string
 const (
 	RoleAdmin Role = "admin"
 	RoleUser  Role = "user"
+	RoleStaff Role = "staff"
 )
 
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleAdmin, RoleUser, RoleStaff:
+		return true
+	}
+	return false
+}
+
 type User struct {
-	ID        int        `db:"id" json:"id"`
-	Email     string     `db:"email" json:"email"`
-	Password  string     `db:"password" json:"password"`
-	Role      Role       `db:"role" json:"role"`
-	CreatedAt time.Time  `db:"created_at" json:"created_at"`
-	UpdatedAt *time.Time `db:"updated_at" json:"updated_at"`
-	Profile   Profile    `db:"-" json:"profile"`
+	ID         int        `db:"id" json:"id"`
+	Email      string     `db:"email" json:"email"`
+	Password   string     `db:"password" json:"-"`
+	Role       Role       `db:"role" json:"role"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt  *time.Time `db:"updated_at" json:"updated_at"`
+	DisabledAt *time.Time `db:"disabled_at" json:"disabled_at"`
+	Profile    Profile    `db:"-" json:"profile"`
 }
 
 type Profile struct {
@@ -24,6 +34,8 @@ type Profile struct {
 	FirstName   *string `db:"firstname" json:"firstname" example:"Farid"`
 	LastName    *string `db:"lastname" json:"lastname" example:"Darari"`
 	PhoneNumber *string `db:"phone_number" json:"phone_number" example:"089876543210"`
+	// diisi lewat PUT /profile/avatar, diabaikan oleh PUT /profile
+	AvatarURL *string `db:"avatar" json:"avatar_url" readonly:"true" example:"/img/avatars/1/3f2a9c_avatar.jpg"`
 }
 
 type LoginRequest struct {
@@ -31,11 +43,27 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required" example:"password123"`
 }
 
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required" example:"q3X0f1..."`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" example:"q3X0f1..."`
+}
+
 type RegisterRequest struct {
 	Email       string  `json:"email" binding:"required,email" example:"newuser@mail.com"`
 	Password    string  `json:"password" binding:"required" example:"mypassword"`
-	Role        string  `json:"role" example:"user"`
 	FirstName   *string `json:"firstname" example:"Farid"`
 	LastName    *string `json:"lastname" example:"Rhamadhan"`
 	PhoneNumber *string `json:"phone_number" example:"08123456789"`
 }
+
+// untuk admin
+type UpdateUserRoleRequest struct {
+	Role Role `json:"role" binding:"required" example:"admin"`
+}
+
+type UpdateUserStatusRequest struct {
+	Disabled *bool `json:"disabled" binding:"required" example:"true"`
+}
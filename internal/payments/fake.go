@@ -0,0 +1,116 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// FakeProvider adalah payment gateway in-process untuk development dan testing offline.
+// Charge ID deterministik (fake_<order>_<n>) dan webhook ditandatangani HMAC-SHA256
+// dengan secret yang sama, lihat Sign.
+type FakeProvider struct {
+	secret []byte
+
+	mu      sync.Mutex
+	seq     map[int]int
+	charges map[string]*Charge
+}
+
+func NewFakeProvider(secret string) (*FakeProvider, error) {
+	if secret == "" {
+		return nil, ErrMissingSecret
+	}
+	return &FakeProvider{
+		secret:  []byte(secret),
+		seq:     make(map[int]int),
+		charges: make(map[string]*Charge),
+	}, nil
+}
+
+func (fp *FakeProvider) Name() string {
+	return "fake"
+}
+
+func (fp *FakeProvider) CreateCharge(ctx context.Context, orderID, amount int) (*Charge, error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	fp.seq[orderID]++
+	charge := &Charge{
+		ID:      fmt.Sprintf("fake_%d_%d", orderID, fp.seq[orderID]),
+		OrderID: orderID,
+		Amount:  amount,
+		Status:  StatusPending,
+	}
+	charge.CheckoutURL = "/payments/fake/" + charge.ID
+	fp.charges[charge.ID] = charge
+
+	copied := *charge
+	return &copied, nil
+}
+
+func (fp *FakeProvider) GetCharge(ctx context.Context, chargeID string) (*Charge, error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	charge, ok := fp.charges[chargeID]
+	if !ok {
+		return nil, ErrChargeNotFound
+	}
+	copied := *charge
+	return &copied, nil
+}
+
+// Refund mengembalikan sebagian atau seluruh dana charge yang sudah dibayar
+func (fp *FakeProvider) Refund(ctx context.Context, chargeID string, amount int) (*Charge, error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	charge, ok := fp.charges[chargeID]
+	if !ok {
+		return nil, ErrChargeNotFound
+	}
+	if charge.Status != StatusSucceeded || amount <= 0 || charge.Refunded+amount > charge.Amount {
+		return nil, ErrNotRefundable
+	}
+	charge.Refunded += amount
+	if charge.Refunded == charge.Amount {
+		charge.Status = StatusRefunded
+	}
+	copied := *charge
+	return &copied, nil
+}
+
+func (fp *FakeProvider) ParseWebhook(payload []byte, signature string) (*Event, error) {
+	if !hmac.Equal([]byte(fp.Sign(payload)), []byte(signature)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	if event.ChargeID == "" || !event.Status.IsValid() {
+		return nil, fmt.Errorf("invalid webhook payload")
+	}
+
+	// status charge in-memory ikut diperbarui supaya GetCharge konsisten dengan webhook
+	fp.mu.Lock()
+	if charge, ok := fp.charges[event.ChargeID]; ok {
+		charge.Status = event.Status
+	}
+	fp.mu.Unlock()
+	return &event, nil
+}
+
+// Sign menghasilkan signature hex HMAC-SHA256 untuk payload webhook
+func (fp *FakeProvider) Sign(payload []byte) string {
+	mac := hmac.New(sha256.New, fp.secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,195 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func newTestProvider(t *testing.T) *FakeProvider {
+	t.Helper()
+	fp, err := NewFakeProvider("test-secret")
+	if err != nil {
+		t.Fatalf("NewFakeProvider: %v", err)
+	}
+	return fp
+}
+
+func TestNewFakeProviderRequiresSecret(t *testing.T) {
+	if _, err := NewFakeProvider(""); !errors.Is(err, ErrMissingSecret) {
+		t.Fatalf("err = %v, want ErrMissingSecret", err)
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		secret   string
+		ginMode  string
+		wantErr  error
+	}{
+		{name: "provider kosong", provider: "", secret: "s", wantErr: ErrProviderNotConfigured},
+		{name: "fake tanpa secret", provider: "fake", secret: "", wantErr: ErrProviderNotConfigured},
+		{name: "fake di release mode", provider: "fake", secret: "s", ginMode: "release", wantErr: ErrProviderNotConfigured},
+		{name: "fake di debug mode", provider: "fake", secret: "s", ginMode: "debug"},
+		{name: "fake tanpa GIN_MODE", provider: "fake", secret: "s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PAYMENT_PROVIDER", tt.provider)
+			t.Setenv("PAYMENT_WEBHOOK_SECRET", tt.secret)
+			t.Setenv("GIN_MODE", tt.ginMode)
+
+			provider, err := NewProvider()
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || provider != nil {
+					t.Fatalf("NewProvider() = (%v, %v), want (nil, %v)", provider, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if provider.Name() != "fake" {
+				t.Fatalf("Name() = %q, want fake", provider.Name())
+			}
+		})
+	}
+}
+
+func TestNewProviderUnknownName(t *testing.T) {
+	t.Setenv("PAYMENT_PROVIDER", "paypal")
+	t.Setenv("PAYMENT_WEBHOOK_SECRET", "s")
+
+	// nama yang salah ketik harus menggagalkan startup, bukan diam-diam mematikan pembayaran
+	_, err := NewProvider()
+	if err == nil || errors.Is(err, ErrProviderNotConfigured) {
+		t.Fatalf("err = %v, want unknown provider error", err)
+	}
+}
+
+func TestParseWebhookSignature(t *testing.T) {
+	fp := newTestProvider(t)
+	other, err := NewFakeProvider("other-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := []byte(`{"charge_id":"fake_1_1","status":"succeeded"}`)
+
+	tests := []struct {
+		name      string
+		payload   []byte
+		signature string
+		wantErr   error
+		wantEvent *Event
+	}{
+		{
+			name:      "signature valid",
+			payload:   payload,
+			signature: fp.Sign(payload),
+			wantEvent: &Event{ChargeID: "fake_1_1", Status: StatusSucceeded},
+		},
+		{name: "signature kosong", payload: payload, signature: "", wantErr: ErrInvalidSignature},
+		{name: "secret berbeda", payload: payload, signature: other.Sign(payload), wantErr: ErrInvalidSignature},
+		{
+			name:      "payload diubah",
+			payload:   []byte(`{"charge_id":"fake_2_1","status":"succeeded"}`),
+			signature: fp.Sign(payload),
+			wantErr:   ErrInvalidSignature,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := fp.ParseWebhook(tt.payload, tt.signature)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *event != *tt.wantEvent {
+				t.Fatalf("event = %+v, want %+v", *event, *tt.wantEvent)
+			}
+		})
+	}
+}
+
+func TestParseWebhookInvalidPayload(t *testing.T) {
+	fp := newTestProvider(t)
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{name: "bukan json", payload: `not-json`},
+		{name: "tanpa charge id", payload: `{"status":"succeeded"}`},
+		{name: "status tidak dikenal", payload: `{"charge_id":"fake_1_1","status":"paid"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := []byte(tt.payload)
+			if _, err := fp.ParseWebhook(payload, fp.Sign(payload)); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestRefund(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		paid       bool
+		refunds    []int
+		wantErr    bool
+		wantStatus Status
+	}{
+		{name: "charge belum dibayar", paid: false, refunds: []int{100}, wantErr: true},
+		{name: "refund sebagian", paid: true, refunds: []int{40}, wantStatus: StatusSucceeded},
+		{name: "refund penuh", paid: true, refunds: []int{100}, wantStatus: StatusRefunded},
+		{name: "refund bertahap sampai penuh", paid: true, refunds: []int{60, 40}, wantStatus: StatusRefunded},
+		{name: "melebihi jumlah charge", paid: true, refunds: []int{60, 41}, wantErr: true},
+		{name: "jumlah nol", paid: true, refunds: []int{0}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp := newTestProvider(t)
+			charge, err := fp.CreateCharge(ctx, 1, 100)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.paid {
+				payload := []byte(`{"charge_id":"` + charge.ID + `","status":"succeeded"}`)
+				if _, err := fp.ParseWebhook(payload, fp.Sign(payload)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var refundErr error
+			for _, amount := range tt.refunds {
+				if _, refundErr = fp.Refund(ctx, charge.ID, amount); refundErr != nil {
+					break
+				}
+			}
+			if tt.wantErr {
+				if !errors.Is(refundErr, ErrNotRefundable) {
+					t.Fatalf("err = %v, want ErrNotRefundable", refundErr)
+				}
+				return
+			}
+			if refundErr != nil {
+				t.Fatalf("unexpected error: %v", refundErr)
+			}
+			got, err := fp.GetCharge(ctx, charge.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Status != tt.wantStatus {
+				t.Fatalf("status = %s, want %s", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrChargeNotFound   = errors.New("charge not found")
+	ErrNotRefundable    = errors.New("charge cannot be refunded")
+	ErrMissingSecret    = errors.New("PAYMENT_WEBHOOK_SECRET is not set")
+	// ErrProviderNotConfigured berarti tidak ada payment gateway yang bisa dipakai. Server tetap jalan
+	// tanpa fitur pembayaran online.
+	ErrProviderNotConfigured = errors.New("payment provider is not configured")
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusRefunded  Status = "refunded"
+)
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusSucceeded, StatusFailed, StatusRefunded:
+		return true
+	}
+	return false
+}
+
+// Charge adalah tagihan di sisi payment gateway untuk satu order
+type Charge struct {
+	ID          string `json:"charge_id"`
+	OrderID     int    `json:"order_id"`
+	Amount      int    `json:"amount"`
+	Refunded    int    `json:"refunded"`
+	Status      Status `json:"status"`
+	CheckoutURL string `json:"checkout_url"`
+}
+
+// Event adalah isi webhook dari payment gateway yang sudah diverifikasi
+type Event struct {
+	ChargeID string `json:"charge_id"`
+	Status   Status `json:"status"`
+}
+
+// PaymentProvider adalah kontrak payment gateway. Implementasi baru cukup memenuhi interface ini
+// lalu didaftarkan di NewProvider.
+type PaymentProvider interface {
+	Name() string
+	CreateCharge(ctx context.Context, orderID, amount int) (*Charge, error)
+	GetCharge(ctx context.Context, chargeID string) (*Charge, error)
+	Refund(ctx context.Context, chargeID string, amount int) (*Charge, error)
+	// ParseWebhook memverifikasi signature lalu membaca isi webhook
+	ParseWebhook(payload []byte, signature string) (*Event, error)
+}
+
+// NewProvider memilih payment gateway dari env PAYMENT_PROVIDER.
+// Kalau kosong, atau "fake" dipakai saat GIN_MODE=release / tanpa PAYMENT_WEBHOOK_SECRET,
+// error-nya ErrProviderNotConfigured. Nama provider yang tidak dikenal selalu error biasa.
+func NewProvider() (PaymentProvider, error) {
+	secret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+	switch name := os.Getenv("PAYMENT_PROVIDER"); name {
+	case "":
+		return nil, fmt.Errorf("%w: PAYMENT_PROVIDER is not set", ErrProviderNotConfigured)
+	case "fake":
+		if os.Getenv("GIN_MODE") == "release" {
+			return nil, fmt.Errorf("%w: fake payment provider cannot be used when GIN_MODE=release", ErrProviderNotConfigured)
+		}
+		fp, err := NewFakeProvider(secret)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrProviderNotConfigured, err)
+		}
+		return fp, nil
+	default:
+		return nil, errors.New("unknown payment provider " + name)
+	}
+}
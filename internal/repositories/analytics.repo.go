@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// analyticsGroups memetakan group_by ke kolom id dan nama grup
+var analyticsGroups = map[models.AnalyticsGroup]struct{ id, name string }{
+	models.GroupByMovie:    {"m.id", "m.title"},
+	models.GroupBySchedule: {"sch.id", "m.title || ' - ' || c.name || ' ' || sch.date::date::text || ' ' || LEFT(t.time::text, 5)"},
+	models.GroupByCinema:   {"c.id", "c.name"},
+	models.GroupByLocation: {"l.id", "l.location"},
+}
+
+type AnalyticsRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewAnalyticsRepo(db *pgxpool.Pool) *AnalyticsRepo {
+	return &AnalyticsRepo{db: db}
+}
+
+// GetSales menghitung revenue, tiket terjual dan okupansi per grup untuk schedule yang tayang di rentang tanggal filter.
+// Kapasitas schedule adalah jumlah kursi layout cinema-nya (atau kursi global) yang tidak diblokir.
+// Hanya order paid dan used yang dihitung sebagai penjualan.
+func (ar *AnalyticsRepo) GetSales(ctx context.Context, filter models.AnalyticsFilter) ([]models.SalesStat, error) {
+	group, ok := analyticsGroups[filter.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("invalid analytics group: %s", filter.GroupBy)
+	}
+	bucket := "NULL::timestamp"
+	if filter.Bucket != models.BucketNone {
+		bucket = fmt.Sprintf("date_trunc('%s', sch.date::timestamp)", filter.Bucket)
+	}
+
+	// nama kolom dan bucket diambil dari nilai yang sudah divalidasi, bukan dari input bebas
+	sql := fmt.Sprintf(`
+		WITH sch AS (
+			SELECT sc.id, sc.date, sc.movies_id, sc.cinemas_id, sc.locations_id, sc.times_id,
+			       (
+			           SELECT COUNT(*)
+			           FROM seats s
+			           WHERE NOT s.is_blocked
+			           AND s.cinemas_id IS NOT DISTINCT FROM (
+			               CASE WHEN EXISTS (SELECT 1 FROM seats x WHERE x.cinemas_id = sc.cinemas_id) THEN sc.cinemas_id END
+			           )
+			       ) AS capacity
+			FROM schedules sc
+			WHERE sc.date::date BETWEEN $1::date AND $2::date
+		),
+		sold AS (
+			SELECT o.schedules_id, COUNT(*) AS tickets, SUM(os.price) AS revenue
+			FROM orders o
+			INNER JOIN orders_seats os ON os.orders_id = o.id
+			WHERE o.status IN ('paid', 'used')
+			AND o.schedules_id IN (SELECT id FROM sch)
+			GROUP BY o.schedules_id
+		)
+		SELECT %[1]s AS bucket, %[2]s AS group_id, %[3]s AS group_name,
+		       COUNT(*), COALESCE(SUM(sold.revenue), 0)::bigint, COALESCE(SUM(sold.tickets), 0)::bigint, SUM(sch.capacity)::bigint
+		FROM sch
+		LEFT JOIN sold ON sold.schedules_id = sch.id
+		INNER JOIN movies m ON m.id = sch.movies_id
+		INNER JOIN cinemas c ON c.id = sch.cinemas_id
+		INNER JOIN locations l ON l.id = sch.locations_id
+		INNER JOIN times t ON t.id = sch.times_id
+		GROUP BY 1, 2, 3
+		ORDER BY 1 ASC NULLS FIRST, 5 DESC, 2 ASC
+	`, bucket, group.id, group.name)
+
+	rows, err := ar.db.Query(ctx, sql, filter.DateFrom.Format("2006-01-02"), filter.DateTo.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []models.SalesStat{}
+	for rows.Next() {
+		var s models.SalesStat
+		if err := rows.Scan(&s.BucketStart, &s.GroupID, &s.GroupName, &s.Schedules, &s.Revenue, &s.TicketsSold, &s.Capacity); err != nil {
+			return nil, err
+		}
+		s.SetOccupancy()
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
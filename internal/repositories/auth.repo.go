@@ -16,7 +16,7 @@ func NewAuthRepo(db *pgxpool.Pool) *AuthRepo {
 }
 
 func (ar *AuthRepo) Login(ctx context.Context, email string) (*models.User, error) {
-	sql := `SELECT id, email, password, role FROM users WHERE email = $1 LIMIT 1`
+	sql := `SELECT id, email, password, role, disabled_at FROM users WHERE email = $1 LIMIT 1`
 
 	var user models.User
 	err := ar.db.QueryRow(ctx, sql, email).Scan(
@@ -24,6 +24,23 @@ func (ar *AuthRepo) Login(ctx context.Context, email string) (*models.User, erro
 		&user.Email,
 		&user.Password,
 		&user.Role,
+		&user.DisabledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (ar *AuthRepo) GetUserByID(ctx context.Context, id int) (*models.User, error) {
+	sql := `SELECT id, email, role, disabled_at FROM users WHERE id = $1`
+
+	var user models.User
+	err := ar.db.QueryRow(ctx, sql, id).Scan(
+		&user.ID,
+		&user.Email,
+		&user.Role,
+		&user.DisabledAt,
 	)
 	if err != nil {
 		return nil, err
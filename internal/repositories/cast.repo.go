@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrCastNotFound = errors.New("cast not found")
+
+type CastRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCastRepo(db *pgxpool.Pool) *CastRepo {
+	return &CastRepo{db: db}
+}
+
+func (cr *CastRepo) GetCasts(ctx context.Context, page, pageSize int, search string) ([]models.Cast, error) {
+	offset := (page - 1) * pageSize
+
+	sql := `
+		SELECT id, name
+		FROM casts
+		WHERE LOWER(name) LIKE LOWER($1)
+		ORDER BY name ASC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := cr.db.Query(ctx, sql, containsPattern(search), pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var casts []models.Cast
+	for rows.Next() {
+		var c models.Cast
+		if err := rows.Scan(&c.ID, &c.Name); err != nil {
+			return nil, err
+		}
+		casts = append(casts, c)
+	}
+	return casts, nil
+}
+
+func (cr *CastRepo) CreateCast(ctx context.Context, name string) (*models.Cast, error) {
+	c := models.Cast{Name: name}
+	if err := cr.db.QueryRow(ctx, `INSERT INTO casts (name) VALUES ($1) RETURNING id`, name).Scan(&c.ID); err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateName
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (cr *CastRepo) UpdateCast(ctx context.Context, id int, name string) error {
+	tag, err := cr.db.Exec(ctx, `UPDATE casts SET name = $1 WHERE id = $2`, name, id)
+	if isUniqueViolation(err) {
+		return ErrDuplicateName
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCastNotFound
+	}
+	return nil
+}
+
+// DeleteCast menghapus cast, kalau force true relasi ke movie ikut dihapus
+func (cr *CastRepo) DeleteCast(ctx context.Context, id int, force bool) error {
+	tx, err := cr.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var linked int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM movies_casts WHERE casts_id = $1`, id).Scan(&linked); err != nil {
+		return err
+	}
+	if linked > 0 && !force {
+		return ErrStillLinked
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM movies_casts WHERE casts_id = $1`, id); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM casts WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCastNotFound
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,381 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrCinemaNotFound   = errors.New("cinema not found")
+	ErrLocationNotFound = errors.New("location not found")
+	ErrTimeNotFound     = errors.New("time slot not found")
+	ErrStillScheduled   = errors.New("still used by one or more schedules")
+)
+
+// DuplicateSeatError dikembalikan kalau layout berisi posisi kursi yang sama lebih dari sekali
+type DuplicateSeatError struct {
+	SeatCode string
+}
+
+func (e *DuplicateSeatError) Error() string {
+	return fmt.Sprintf("duplicate seat %s in layout", e.SeatCode)
+}
+
+// CinemaRepo mengelola data pendukung schedule: cinema, location dan time slot
+type CinemaRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCinemaRepo(db *pgxpool.Pool) *CinemaRepo {
+	return &CinemaRepo{db: db}
+}
+
+// ===========================
+// cinemas
+
+func (cr *CinemaRepo) GetCinemas(ctx context.Context) ([]models.Cinema, error) {
+	rows, err := cr.db.Query(ctx, `SELECT id, name, base_price FROM cinemas ORDER BY name ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cinemas []models.Cinema
+	for rows.Next() {
+		var c models.Cinema
+		if err := rows.Scan(&c.ID, &c.Name, &c.BasePrice); err != nil {
+			return nil, err
+		}
+		cinemas = append(cinemas, c)
+	}
+	return cinemas, rows.Err()
+}
+
+func (cr *CinemaRepo) CreateCinema(ctx context.Context, name string, basePrice int) (*models.Cinema, error) {
+	c := models.Cinema{Name: name, BasePrice: basePrice}
+	err := cr.db.QueryRow(ctx, `INSERT INTO cinemas (name, base_price) VALUES ($1, $2) RETURNING id`, name, basePrice).Scan(&c.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (cr *CinemaRepo) UpdateCinema(ctx context.Context, id int, name string, basePrice int) error {
+	tag, err := cr.db.Exec(ctx, `UPDATE cinemas SET name = $1, base_price = $2 WHERE id = $3`, name, basePrice, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCinemaNotFound
+	}
+	return nil
+}
+
+func (cr *CinemaRepo) DeleteCinema(ctx context.Context, id int) error {
+	var used bool
+	if err := cr.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schedules WHERE cinemas_id = $1)`, id).Scan(&used); err != nil {
+		return err
+	}
+	if used {
+		return ErrStillScheduled
+	}
+
+	tag, err := cr.db.Exec(ctx, `DELETE FROM cinemas WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCinemaNotFound
+	}
+	return nil
+}
+
+// ===========================
+// seat layout
+
+func (cr *CinemaRepo) GetSeatLayout(ctx context.Context, cinemaID int) ([]models.Seat, error) {
+	var exists bool
+	if err := cr.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM cinemas WHERE id = $1)`, cinemaID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrCinemaNotFound
+	}
+
+	sql := `
+		SELECT id, seat_code, row_code, column_number, category, is_blocked
+		FROM seats
+		WHERE cinemas_id = $1
+		ORDER BY row_code ASC, column_number ASC
+	`
+	rows, err := cr.db.Query(ctx, sql, cinemaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seats := []models.Seat{}
+	for rows.Next() {
+		var seat models.Seat
+		if err := rows.Scan(&seat.ID, &seat.SeatCode, &seat.Row, &seat.Column, &seat.Category, &seat.Blocked); err != nil {
+			return nil, err
+		}
+		seats = append(seats, seat)
+	}
+	return seats, rows.Err()
+}
+
+// ReplaceSeatLayout menyimpan layout kursi cinema. Kursi yang sudah ada di-update berdasarkan seat_code
+// supaya order lama tetap valid, kursi yang hilang dari layout dihapus atau diblokir kalau sudah pernah dipesan.
+// Pada layout pertama, order dan hold yang masih menunjuk kursi global dipindah ke kursi cinema dengan seat_code yang sama.
+func (cr *CinemaRepo) ReplaceSeatLayout(ctx context.Context, cinemaID int, seats []models.SeatLayoutItem) error {
+	tx, err := cr.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var lockedID int
+	err = tx.QueryRow(ctx, `SELECT id FROM cinemas WHERE id = $1 FOR UPDATE`, cinemaID).Scan(&lockedID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrCinemaNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	codes := make([]string, 0, len(seats))
+	seen := make(map[string]bool, len(seats))
+	for _, seat := range seats {
+		code := fmt.Sprintf("%s%d", seat.Row, seat.Column)
+		if seen[code] {
+			return &DuplicateSeatError{SeatCode: code}
+		}
+		seen[code] = true
+		codes = append(codes, code)
+
+		_, err := tx.Exec(ctx, `
+			INSERT INTO seats (cinemas_id, seat_code, row_code, column_number, category, is_blocked)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (cinemas_id, seat_code) WHERE cinemas_id IS NOT NULL
+			DO UPDATE SET row_code = EXCLUDED.row_code, column_number = EXCLUDED.column_number,
+			              category = EXCLUDED.category, is_blocked = EXCLUDED.is_blocked
+		`, cinemaID, code, seat.Row, seat.Column, seat.Category, seat.Blocked)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := moveGlobalSeatBookings(ctx, tx, cinemaID, codes); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM seats s
+		WHERE s.cinemas_id = $1 AND NOT (s.seat_code = ANY($2))
+		AND NOT EXISTS (SELECT 1 FROM orders_seats os WHERE os.seats_id = s.id)
+	`, cinemaID, codes)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE seats SET is_blocked = TRUE
+		WHERE cinemas_id = $1 AND NOT (seat_code = ANY($2))
+	`, cinemaID, codes)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// moveGlobalSeatBookings memindahkan orders_seats dan seat_holds_seats milik schedule di cinema ini dari kursi
+// global (cinemas_id NULL) ke kursi layout cinema. Begitu cinema punya layout, availability hanya melihat kursi
+// cinema, jadi tanpa ini kursi yang sudah terjual sebagai kursi global akan terlihat kosong dan bisa dijual lagi.
+// Kursi global yang sudah dipesan tapi tidak ada di layout baru dibuat sebagai kursi terblokir.
+func moveGlobalSeatBookings(ctx context.Context, tx pgx.Tx, cinemaID int, codes []string) error {
+	// hold dan checkout mengunci baris schedule, jadi tidak ada booking baru ke kursi global selama dipindah
+	_, err := tx.Exec(ctx, `SELECT id FROM schedules WHERE cinemas_id = $1 ORDER BY id FOR UPDATE`, cinemaID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO seats (cinemas_id, seat_code, row_code, column_number, category, is_blocked)
+		SELECT $1, g.seat_code, g.row_code, g.column_number, g.category, TRUE
+		FROM seats g
+		WHERE g.cinemas_id IS NULL AND NOT (g.seat_code = ANY($2))
+		AND (
+			EXISTS (
+				SELECT 1 FROM orders_seats os
+				INNER JOIN orders o ON o.id = os.orders_id
+				INNER JOIN schedules sc ON sc.id = o.schedules_id
+				WHERE os.seats_id = g.id AND sc.cinemas_id = $1
+			)
+			OR EXISTS (
+				SELECT 1 FROM seat_holds_seats hs
+				INNER JOIN seat_holds h ON h.id = hs.seat_holds_id
+				INNER JOIN schedules sc ON sc.id = h.schedules_id
+				WHERE hs.seats_id = g.id AND sc.cinemas_id = $1
+			)
+		)
+		ON CONFLICT (cinemas_id, seat_code) WHERE cinemas_id IS NOT NULL DO NOTHING
+	`, cinemaID, codes)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE orders_seats os
+		SET seats_id = c.id
+		FROM orders o, schedules sc, seats g, seats c
+		WHERE o.id = os.orders_id AND sc.id = o.schedules_id AND sc.cinemas_id = $1
+		AND g.id = os.seats_id AND g.cinemas_id IS NULL
+		AND c.cinemas_id = $1 AND c.seat_code = g.seat_code
+	`, cinemaID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE seat_holds_seats hs
+		SET seats_id = c.id
+		FROM seat_holds h, schedules sc, seats g, seats c
+		WHERE h.id = hs.seat_holds_id AND sc.id = h.schedules_id AND sc.cinemas_id = $1
+		AND g.id = hs.seats_id AND g.cinemas_id IS NULL
+		AND c.cinemas_id = $1 AND c.seat_code = g.seat_code
+	`, cinemaID)
+	return err
+}
+
+// ===========================
+// locations
+
+func (cr *CinemaRepo) GetLocations(ctx context.Context) ([]models.Location, error) {
+	rows, err := cr.db.Query(ctx, `SELECT id, location FROM locations ORDER BY location ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var locations []models.Location
+	for rows.Next() {
+		var l models.Location
+		if err := rows.Scan(&l.ID, &l.Location); err != nil {
+			return nil, err
+		}
+		locations = append(locations, l)
+	}
+	return locations, rows.Err()
+}
+
+func (cr *CinemaRepo) CreateLocation(ctx context.Context, location string) (*models.Location, error) {
+	l := models.Location{Location: location}
+	if err := cr.db.QueryRow(ctx, `INSERT INTO locations (location) VALUES ($1) RETURNING id`, location).Scan(&l.ID); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (cr *CinemaRepo) UpdateLocation(ctx context.Context, id int, location string) error {
+	tag, err := cr.db.Exec(ctx, `UPDATE locations SET location = $1 WHERE id = $2`, location, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrLocationNotFound
+	}
+	return nil
+}
+
+func (cr *CinemaRepo) DeleteLocation(ctx context.Context, id int) error {
+	var used bool
+	if err := cr.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schedules WHERE locations_id = $1)`, id).Scan(&used); err != nil {
+		return err
+	}
+	if used {
+		return ErrStillScheduled
+	}
+
+	tag, err := cr.db.Exec(ctx, `DELETE FROM locations WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrLocationNotFound
+	}
+	return nil
+}
+
+// ===========================
+// time slots
+
+func (cr *CinemaRepo) GetTimes(ctx context.Context) ([]models.Time, error) {
+	rows, err := cr.db.Query(ctx, `SELECT id, time::text FROM times ORDER BY time::time ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var times []models.Time
+	for rows.Next() {
+		var t models.Time
+		if err := rows.Scan(&t.ID, &t.Time); err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+	return times, rows.Err()
+}
+
+func (cr *CinemaRepo) CreateTime(ctx context.Context, value string) (*models.Time, error) {
+	t := models.Time{Time: value}
+	if err := cr.db.QueryRow(ctx, `INSERT INTO times (time) VALUES ($1) RETURNING id`, value).Scan(&t.ID); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (cr *CinemaRepo) UpdateTime(ctx context.Context, id int, value string) error {
+	var used bool
+	if err := cr.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schedules WHERE times_id = $1)`, id).Scan(&used); err != nil {
+		return err
+	}
+	// jam tayang yang sudah dipakai tidak boleh digeser karena bisa membuat schedule bentrok
+	if used {
+		return ErrStillScheduled
+	}
+
+	tag, err := cr.db.Exec(ctx, `UPDATE times SET time = $1 WHERE id = $2`, value, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTimeNotFound
+	}
+	return nil
+}
+
+func (cr *CinemaRepo) DeleteTime(ctx context.Context, id int) error {
+	var used bool
+	if err := cr.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schedules WHERE times_id = $1)`, id).Scan(&used); err != nil {
+		return err
+	}
+	if used {
+		return ErrStillScheduled
+	}
+
+	tag, err := cr.db.Exec(ctx, `DELETE FROM times WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTimeNotFound
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// error yang dipakai bersama oleh repo genre dan cast
+var (
+	ErrDuplicateName = errors.New("name already exists")
+	ErrStillLinked   = errors.New("still linked to one or more movies")
+)
+
+// isUniqueViolation mengecek apakah err berasal dari pelanggaran unique index (SQLSTATE 23505)
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern membuat pattern LIKE "mengandung search", % dan _ dari input user dicari apa adanya
+func containsPattern(search string) string {
+	return "%" + likeEscaper.Replace(search) + "%"
+}
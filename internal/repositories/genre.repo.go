@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrGenreNotFound = errors.New("genre not found")
+
+type GenreRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewGenreRepo(db *pgxpool.Pool) *GenreRepo {
+	return &GenreRepo{db: db}
+}
+
+func (gr *GenreRepo) GetGenres(ctx context.Context, search string) ([]models.Genre, error) {
+	sql := `
+		SELECT id, name
+		FROM genres
+		WHERE LOWER(name) LIKE LOWER($1)
+		ORDER BY name ASC
+	`
+	rows, err := gr.db.Query(ctx, sql, containsPattern(search))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var genres []models.Genre
+	for rows.Next() {
+		var g models.Genre
+		if err := rows.Scan(&g.ID, &g.Name); err != nil {
+			return nil, err
+		}
+		genres = append(genres, g)
+	}
+	return genres, nil
+}
+
+func (gr *GenreRepo) CreateGenre(ctx context.Context, name string) (*models.Genre, error) {
+	g := models.Genre{Name: name}
+	if err := gr.db.QueryRow(ctx, `INSERT INTO genres (name) VALUES ($1) RETURNING id`, name).Scan(&g.ID); err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrDuplicateName
+		}
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (gr *GenreRepo) UpdateGenre(ctx context.Context, id int, name string) error {
+	tag, err := gr.db.Exec(ctx, `UPDATE genres SET name = $1 WHERE id = $2`, name, id)
+	if isUniqueViolation(err) {
+		return ErrDuplicateName
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrGenreNotFound
+	}
+	return nil
+}
+
+// DeleteGenre menghapus genre, kalau force true relasi ke movie ikut dihapus
+func (gr *GenreRepo) DeleteGenre(ctx context.Context, id int, force bool) error {
+	tx, err := gr.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var linked int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM movies_genres WHERE genres_id = $1`, id).Scan(&linked); err != nil {
+		return err
+	}
+	if linked > 0 && !force {
+		return ErrStillLinked
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM movies_genres WHERE genres_id = $1`, id); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM genres WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrGenreNotFound
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,210 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrScheduleNotFound = errors.New("schedule not found")
+	ErrInvalidSeat      = errors.New("one or more seats do not exist in this schedule or are blocked")
+	ErrHoldNotFound     = errors.New("seat hold not found or expired")
+	ErrHoldMismatch     = errors.New("selected seats do not match the seat hold")
+)
+
+type HoldRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewHoldRepo(db *pgxpool.Pool) *HoldRepo {
+	return &HoldRepo{db: db}
+}
+
+// CreateHold mengunci kursi untuk schedule tertentu selama duration.
+// Hold lama milik user yang sama untuk schedule tersebut otomatis diganti.
+func (hr *HoldRepo) CreateHold(ctx context.Context, userID, scheduleID int, seatIDs []int, duration time.Duration) (*models.SeatHold, error) {
+	seatIDs = uniqueInts(seatIDs)
+
+	tx, err := hr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// lock baris schedule supaya pembuatan hold untuk schedule yang sama berjalan serial
+	var lockedID int
+	err = tx.QueryRow(ctx, `SELECT id FROM schedules WHERE id = $1 FOR UPDATE`, scheduleID).Scan(&lockedID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM seat_holds
+		WHERE schedules_id = $1 AND (expires_at <= NOW() OR users_id = $2)
+	`, scheduleID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkScheduleSeats(ctx, tx, scheduleID, seatIDs); err != nil {
+		return nil, err
+	}
+
+	if err := checkSeatsAvailable(ctx, tx, scheduleID, seatIDs); err != nil {
+		return nil, err
+	}
+
+	hold := models.SeatHold{UserID: userID, ScheduleID: scheduleID}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO seat_holds (users_id, schedules_id, expires_at, created_at)
+		VALUES ($1, $2, NOW() + make_interval(secs => $3), NOW())
+		RETURNING id, expires_at, created_at
+	`, userID, scheduleID, duration.Seconds()).Scan(&hold.ID, &hold.ExpiresAt, &hold.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, seatID := range seatIDs {
+		_, err := tx.Exec(ctx, `INSERT INTO seat_holds_seats (seat_holds_id, seats_id) VALUES ($1, $2)`, hold.ID, seatID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT s.id, s.seat_code
+		FROM seats s
+		INNER JOIN seat_holds_seats hs ON hs.seats_id = s.id
+		WHERE hs.seat_holds_id = $1
+		ORDER BY s.seat_code ASC
+	`, hold.ID)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var seat models.Seat
+		if err := rows.Scan(&seat.ID, &seat.SeatCode); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		hold.Seats = append(hold.Seats, seat)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+func (hr *HoldRepo) ReleaseHold(ctx context.Context, userID, holdID int) error {
+	tag, err := hr.db.Exec(ctx, `DELETE FROM seat_holds WHERE id = $1 AND users_id = $2`, holdID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrHoldNotFound
+	}
+	return nil
+}
+
+// checkScheduleSeats memastikan semua kursi ada di layout schedule dan tidak diblokir
+func checkScheduleSeats(ctx context.Context, q querier, scheduleID int, seatIDs []int) error {
+	var found int
+	err := q.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM seats s
+		WHERE s.id = ANY($2) AND NOT s.is_blocked
+		AND `+scheduleSeatsFilter, scheduleID, seatIDs).Scan(&found)
+	if err != nil {
+		return err
+	}
+	if found != len(seatIDs) {
+		return ErrInvalidSeat
+	}
+	return nil
+}
+
+// consumeHold memvalidasi hold di dalam transaksi order lalu menghapusnya.
+// Kursi yang dipesan harus sama persis dengan kursi yang di-hold.
+func consumeHold(ctx context.Context, tx pgx.Tx, holdID, userID, scheduleID int, seatIDs []int) error {
+	if err := checkHold(ctx, tx, holdID, userID, scheduleID, seatIDs, true); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, `DELETE FROM seat_holds WHERE id = $1`, holdID)
+	return err
+}
+
+// checkHold memastikan hold milik user, belum expired dan kursinya sama persis dengan seatIDs.
+// lock true mengunci baris hold sampai transaksi selesai.
+func checkHold(ctx context.Context, q querier, holdID, userID, scheduleID int, seatIDs []int, lock bool) error {
+	sql := `
+		SELECT id FROM seat_holds
+		WHERE id = $1 AND users_id = $2 AND schedules_id = $3 AND expires_at > NOW()
+	`
+	if lock {
+		sql += ` FOR UPDATE`
+	}
+	var holdRowID int
+	err := q.QueryRow(ctx, sql, holdID, userID, scheduleID).Scan(&holdRowID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrHoldNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	rows, err := q.Query(ctx, `SELECT seats_id FROM seat_holds_seats WHERE seat_holds_id = $1`, holdID)
+	if err != nil {
+		return err
+	}
+	held := make(map[int]bool)
+	for rows.Next() {
+		var seatID int
+		if err := rows.Scan(&seatID); err != nil {
+			rows.Close()
+			return err
+		}
+		held[seatID] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	seatIDs = uniqueInts(seatIDs)
+	if len(seatIDs) != len(held) {
+		return ErrHoldMismatch
+	}
+	for _, seatID := range seatIDs {
+		if !held[seatID] {
+			return ErrHoldMismatch
+		}
+	}
+	return nil
+}
+
+func uniqueInts(values []int) []int {
+	seen := make(map[int]bool, len(values))
+	result := make([]int, 0, len(values))
+	for _, v := range values {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
@@ -2,11 +2,26 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var ErrMovieNotFound = errors.New("movie not found")
+
+// MissingReferenceError dikembalikan kalau ada id relasi (genre, cast, dll) yang tidak ada di database
+type MissingReferenceError struct {
+	Kind string
+	IDs  []int
+}
+
+func (e *MissingReferenceError) Error() string {
+	return fmt.Sprintf("%s not found: %v", e.Kind, e.IDs)
+}
+
 type MovieRepo struct {
 	db *pgxpool.Pool
 }
@@ -172,23 +187,52 @@ func (mr *MovieRepo) GetMoviesWithPagination(ctx context.Context, page, pageSize
 	return movies, nil
 }
 
-func (mr *MovieRepo) GetSchedule(ctx context.Context, movieID int) ([]models.Schedule, error) {
+func (mr *MovieRepo) GetSchedule(ctx context.Context, movieID int, filter models.ScheduleFilter) ([]models.ScheduleDetail, error) {
 	sql := `
-		SELECT id, movies_id, cinemas_id, times_id, locations_id, date
-		FROM schedules
-		WHERE movies_id = $1
-		ORDER BY date ASC
+		SELECT s.id, s.movies_id, s.date, COALESCE(NULLIF(s.price, 0), c.base_price),
+		       c.id, c.name, c.base_price, l.id, l.location, t.id, t.time::text
+		FROM schedules s
+		INNER JOIN cinemas c ON c.id = s.cinemas_id
+		INNER JOIN locations l ON l.id = s.locations_id
+		INNER JOIN times t ON t.id = s.times_id
+		WHERE s.movies_id = $1
 	`
-	rows, err := mr.db.Query(ctx, sql, movieID)
+	args := []any{movieID}
+	if filter.Date != nil {
+		args = append(args, filter.Date.Format("2006-01-02"))
+		sql += fmt.Sprintf(" AND s.date::date = $%d::date", len(args))
+	}
+	if filter.LocationID > 0 {
+		args = append(args, filter.LocationID)
+		sql += fmt.Sprintf(" AND s.locations_id = $%d", len(args))
+	}
+	if filter.CinemaID > 0 {
+		args = append(args, filter.CinemaID)
+		sql += fmt.Sprintf(" AND s.cinemas_id = $%d", len(args))
+	}
+	if filter.TimeFrom != "" {
+		args = append(args, filter.TimeFrom)
+		sql += fmt.Sprintf(" AND t.time::time >= $%d::time", len(args))
+	}
+	if filter.TimeTo != "" {
+		args = append(args, filter.TimeTo)
+		sql += fmt.Sprintf(" AND t.time::time <= $%d::time", len(args))
+	}
+	sql += " ORDER BY s.date ASC, t.time::time ASC"
+
+	rows, err := mr.db.Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var schedules []models.Schedule
+	var schedules []models.ScheduleDetail
 	for rows.Next() {
-		var s models.Schedule
-		if err := rows.Scan(&s.ID, &s.MovieID, &s.CinemaID, &s.TimeID, &s.LocationID, &s.Date); err != nil {
+		var s models.ScheduleDetail
+		if err := rows.Scan(
+			&s.ID, &s.MovieID, &s.Date, &s.Price,
+			&s.Cinema.ID, &s.Cinema.Name, &s.Cinema.BasePrice, &s.Location.ID, &s.Location.Location, &s.Time.ID, &s.Time.Time,
+		); err != nil {
 			return nil, err
 		}
 		schedules = append(schedules, s)
@@ -196,15 +240,33 @@ func (mr *MovieRepo) GetSchedule(ctx context.Context, movieID int) ([]models.Sch
 	return schedules, nil
 }
 
+// scheduleSeatsFilter membatasi seats s ke layout cinema milik schedule $1.
+// Cinema yang belum punya layout memakai kursi global (cinemas_id NULL).
+const scheduleSeatsFilter = `
+	s.cinemas_id IS NOT DISTINCT FROM (
+		SELECT CASE WHEN EXISTS (SELECT 1 FROM seats x WHERE x.cinemas_id = sc.cinemas_id) THEN sc.cinemas_id END
+		FROM schedules sc
+		WHERE sc.id = $1
+	)
+`
+
 func (mr *MovieRepo) GetAvailableSeats(ctx context.Context, scheduleID int) ([]models.Seat, error) {
 	sql := `
 		SELECT s.id, s.seat_code
 		FROM seats s
-		WHERE s.id NOT IN (
+		WHERE ` + scheduleSeatsFilter + `
+		AND NOT s.is_blocked
+		AND s.id NOT IN (
 			SELECT os.seats_id
 			FROM orders_seats os
 			INNER JOIN orders o ON o.id = os.orders_id
-			WHERE o.schedules_id = $1
+			WHERE o.schedules_id = $1 AND ` + activeOrderFilter + `
+		)
+		AND s.id NOT IN (
+			SELECT hs.seats_id
+			FROM seat_holds_seats hs
+			INNER JOIN seat_holds h ON h.id = hs.seat_holds_id
+			WHERE h.schedules_id = $1 AND h.expires_at > NOW()
 		)
 		ORDER BY s.seat_code ASC
 	`
@@ -225,6 +287,63 @@ func (mr *MovieRepo) GetAvailableSeats(ctx context.Context, scheduleID int) ([]m
 	return seats, nil
 }
 
+// GetSeatMap mengembalikan seluruh kursi schedule dikelompokkan per baris beserta statusnya
+func (mr *MovieRepo) GetSeatMap(ctx context.Context, scheduleID int) (*models.SeatMap, error) {
+	seatMap := models.SeatMap{ScheduleID: scheduleID, Rows: []models.SeatRow{}}
+	err := mr.db.QueryRow(ctx, `SELECT cinemas_id FROM schedules WHERE id = $1`, scheduleID).Scan(&seatMap.CinemaID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sql := `
+		SELECT s.id, s.seat_code, COALESCE(s.row_code, ''), COALESCE(s.column_number, 0), s.category, s.is_blocked,
+		       CASE
+		           WHEN s.is_blocked THEN 'blocked'
+		           WHEN EXISTS (
+		               SELECT 1
+		               FROM orders_seats os
+		               INNER JOIN orders o ON o.id = os.orders_id
+		               WHERE o.schedules_id = $1 AND os.seats_id = s.id AND ` + activeOrderFilter + `
+		           ) THEN 'sold'
+		           WHEN EXISTS (
+		               SELECT 1
+		               FROM seat_holds_seats hs
+		               INNER JOIN seat_holds h ON h.id = hs.seat_holds_id
+		               WHERE h.schedules_id = $1 AND h.expires_at > NOW() AND hs.seats_id = s.id
+		           ) THEN 'held'
+		           ELSE 'available'
+		       END
+		FROM seats s
+		WHERE ` + scheduleSeatsFilter + `
+		ORDER BY s.row_code ASC, s.column_number ASC, s.seat_code ASC
+	`
+	rows, err := mr.db.Query(ctx, sql, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var seat models.Seat
+		if err := rows.Scan(&seat.ID, &seat.SeatCode, &seat.Row, &seat.Column, &seat.Category, &seat.Blocked, &seat.Status); err != nil {
+			return nil, err
+		}
+		last := len(seatMap.Rows) - 1
+		if last < 0 || seatMap.Rows[last].Row != seat.Row {
+			seatMap.Rows = append(seatMap.Rows, models.SeatRow{Row: seat.Row})
+			last++
+		}
+		seatMap.Rows[last].Seats = append(seatMap.Rows[last].Seats, seat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &seatMap, nil
+}
+
 func (mr *MovieRepo) GetMovieDetail(ctx context.Context, id int) (*models.Movie, error) {
 	sql := `
 		SELECT id, backdrop_path, overview, popularity, poster_path,
@@ -301,7 +420,58 @@ func (mr *MovieRepo) DeleteMovie(ctx context.Context, id int) error {
 	return err
 }
 
-func (mr *MovieRepo) UpdateMovie(ctx context.Context, movie models.Movie) error {
+func (mr *MovieRepo) CreateMovie(ctx context.Context, movie models.Movie, genreIDs, castIDs []int) (int, error) {
+	tx, err := mr.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	genreIDs = uniqueInts(genreIDs)
+	castIDs = uniqueInts(castIDs)
+	if err := checkReferences(ctx, tx, "genres", "genre", genreIDs); err != nil {
+		return 0, err
+	}
+	if err := checkReferences(ctx, tx, "casts", "cast", castIDs); err != nil {
+		return 0, err
+	}
+
+	sql := `
+		INSERT INTO movies (title, poster_path, backdrop_path, overview,
+		                    release_date, duration, director_name, popularity, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING id
+	`
+	var id int
+	err = tx.QueryRow(ctx, sql,
+		movie.Title, movie.Poster, movie.Backdrop, movie.Overview,
+		movie.ReleaseDate, movie.Duration, movie.Director, movie.Popularity,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := replaceMovieLinks(ctx, tx, id, "movies_genres", "genres_id", genreIDs); err != nil {
+		return 0, err
+	}
+	if err := replaceMovieLinks(ctx, tx, id, "movies_casts", "casts_id", castIDs); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// UpdateMovie mengubah data movie. genreIDs / castIDs bernilai nil berarti relasi tidak diubah.
+func (mr *MovieRepo) UpdateMovie(ctx context.Context, movie models.Movie, genreIDs, castIDs *[]int) error {
+	tx, err := mr.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	sql := `
 		UPDATE movies
 		SET title=$1, poster_path=$2, backdrop_path=$3, overview=$4,
@@ -309,10 +479,180 @@ func (mr *MovieRepo) UpdateMovie(ctx context.Context, movie models.Movie) error
 		    updated_at=NOW()
 		WHERE id=$9
 	`
-	_, err := mr.db.Exec(ctx, sql,
+	tag, err := tx.Exec(ctx, sql,
 		movie.Title, movie.Poster, movie.Backdrop, movie.Overview,
 		movie.ReleaseDate, movie.Duration, movie.Director, movie.Popularity,
 		movie.ID,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrMovieNotFound
+	}
+
+	if genreIDs != nil {
+		ids := uniqueInts(*genreIDs)
+		if err := checkReferences(ctx, tx, "genres", "genre", ids); err != nil {
+			return err
+		}
+		if err := replaceMovieLinks(ctx, tx, movie.ID, "movies_genres", "genres_id", ids); err != nil {
+			return err
+		}
+	}
+	if castIDs != nil {
+		ids := uniqueInts(*castIDs)
+		if err := checkReferences(ctx, tx, "casts", "cast", ids); err != nil {
+			return err
+		}
+		if err := replaceMovieLinks(ctx, tx, movie.ID, "movies_casts", "casts_id", ids); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// checkReferences memastikan semua ids ada di table, table selalu berasal dari konstanta di kode
+func checkReferences(ctx context.Context, tx pgx.Tx, table, kind string, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	rows, err := tx.Query(ctx, fmt.Sprintf(`SELECT id FROM %s WHERE id = ANY($1)`, table), ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	found := make(map[int]bool, len(ids))
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		found[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var missing []int
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingReferenceError{Kind: kind, IDs: missing}
+	}
+	return nil
+}
+
+// movieImageColumns memetakan jenis gambar movie ke kolomnya
+var movieImageColumns = map[string]string{
+	"poster":   "poster_path",
+	"backdrop": "backdrop_path",
+}
+
+// UpdateMovieImage mengganti path poster / backdrop movie
+func (mr *MovieRepo) UpdateMovieImage(ctx context.Context, id int, kind, path string) error {
+	column, ok := movieImageColumns[kind]
+	if !ok {
+		return fmt.Errorf("invalid movie image kind: %s", kind)
+	}
+	tag, err := mr.db.Exec(ctx, fmt.Sprintf(`UPDATE movies SET %s = $1, updated_at = NOW() WHERE id = $2`, column), path, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrMovieNotFound
+	}
+	return nil
+}
+
+// UpsertImportedMovie membuat atau memperbarui movie berdasarkan tmdb_id. Genre dan cast dicari berdasarkan nama
+// (tidak case sensitive) dan dibuat kalau belum ada, lalu relasi movie diganti sesuai isi import.
+// Poster dan backdrop yang sudah ada (misalnya hasil upload admin) tidak ditimpa oleh path TMDB.
+func (mr *MovieRepo) UpsertImportedMovie(ctx context.Context, movie models.MovieImport) (id int, created bool, err error) {
+	tx, err := mr.db.Begin(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	genreIDs, err := findOrCreateNames(ctx, tx, "genres", movie.Genres)
+	if err != nil {
+		return 0, false, err
+	}
+	castIDs, err := findOrCreateNames(ctx, tx, "casts", movie.Casts)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// xmax = 0 hanya untuk baris yang baru di-insert
+	err = tx.QueryRow(ctx, `
+		INSERT INTO movies (tmdb_id, title, poster_path, backdrop_path, overview,
+		                    release_date, duration, director_name, popularity, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+		ON CONFLICT (tmdb_id) WHERE tmdb_id IS NOT NULL DO UPDATE
+		SET title = EXCLUDED.title,
+		    poster_path = COALESCE(NULLIF(movies.poster_path, ''), EXCLUDED.poster_path),
+		    backdrop_path = COALESCE(NULLIF(movies.backdrop_path, ''), EXCLUDED.backdrop_path),
+		    overview = EXCLUDED.overview, release_date = EXCLUDED.release_date, duration = EXCLUDED.duration,
+		    director_name = EXCLUDED.director_name, popularity = EXCLUDED.popularity, updated_at = NOW()
+		RETURNING id, xmax = 0
+	`, movie.TMDBID, movie.Title, movie.Poster, movie.Backdrop, movie.Overview,
+		movie.ReleaseDate, movie.Duration, movie.Director, movie.Popularity,
+	).Scan(&id, &created)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if err := replaceMovieLinks(ctx, tx, id, "movies_genres", "genres_id", genreIDs); err != nil {
+		return 0, false, err
+	}
+	if err := replaceMovieLinks(ctx, tx, id, "movies_casts", "casts_id", castIDs); err != nil {
+		return 0, false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, false, err
+	}
+	return id, created, nil
+}
+
+// findOrCreateNames mengembalikan id genres / casts untuk setiap nama, table selalu berasal dari konstanta di kode
+func findOrCreateNames(ctx context.Context, tx pgx.Tx, table string, names []string) ([]int, error) {
+	var ids []int
+	for _, name := range names {
+		var id int
+		selectSQL := fmt.Sprintf(`SELECT id FROM %s WHERE LOWER(name) = LOWER($1)`, table)
+		err := tx.QueryRow(ctx, selectSQL, name).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			insertSQL := fmt.Sprintf(`INSERT INTO %s (name) VALUES ($1) ON CONFLICT (LOWER(name)) DO NOTHING RETURNING id`, table)
+			err = tx.QueryRow(ctx, insertSQL, name).Scan(&id)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			// nama yang sama baru saja dibuat oleh transaksi lain, query baru melihat baris yang sudah di-commit
+			err = tx.QueryRow(ctx, selectSQL, name).Scan(&id)
+		}
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return uniqueInts(ids), nil
+}
+
+func replaceMovieLinks(ctx context.Context, tx pgx.Tx, movieID int, table, column string, ids []int) error {
+	if _, err := tx.Exec(ctx, fmt.Sprintf(`DELETE FROM %s WHERE movies_id = $1`, table), movieID); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		_, err := tx.Exec(ctx, fmt.Sprintf(`INSERT INTO %s (movies_id, %s) VALUES ($1, $2)`, table, column), movieID, id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -1,12 +1,66 @@
 package repositories
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/Darari17/be-go-tickitz-app/pkg"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var (
+	ErrOrderNotFound    = errors.New("order not found")
+	ErrInvalidOrderSort = errors.New("invalid order sort")
+)
+
+// OrderTransitionError dikembalikan kalau perpindahan status order tidak diizinkan
+type OrderTransitionError struct {
+	From models.OrderStatus
+	To   models.OrderStatus
+}
+
+func (e *OrderTransitionError) Error() string {
+	return fmt.Sprintf("cannot change order status from %s to %s", e.From, e.To)
+}
+
+// activeOrderFilter adalah kondisi order o yang masih menempati kursi.
+// Order pending yang sudah lewat batas bayar dianggap expired walaupun worker belum mengubah statusnya.
+const activeOrderFilter = `(o.status IN ('paid', 'used') OR (o.status = 'pending' AND o.expires_at > NOW()))`
+
+// orderColumns dipakai bersama scanOrder
+const orderColumns = `
+	o.id, o.qr_code, o.users_id, o.schedules_id, o.payments_id,
+	o.fullname, o.email, o.phone_number, o.service_fee, o.total_price,
+	o.status, o.expires_at, o.paid_at, o.used_at, o.expired_at, o.cancelled_at, o.refunded_at,
+	o.cancel_reason, o.cancelled_by, o.created_at, o.updated_at
+`
+
+func scanOrder(row pgx.Row, order *models.Order) error {
+	return row.Scan(
+		&order.ID, &order.QRCode, &order.UserID, &order.ScheduleID, &order.PaymentID,
+		&order.FullName, &order.Email, &order.Phone, &order.ServiceFee, &order.TotalPrice,
+		&order.Status, &order.ExpiresAt, &order.PaidAt, &order.UsedAt, &order.ExpiredAt, &order.CancelledAt, &order.RefundedAt,
+		&order.CancelReason, &order.CancelledBy, &order.CreatedAt, &order.UpdatedAt,
+	)
+}
+
+// SeatTakenError dikembalikan kalau ada kursi yang sudah dipesan atau di-hold user lain
+// untuk schedule yang sama.
+type SeatTakenError struct {
+	SeatCodes []string
+}
+
+func (e *SeatTakenError) Error() string {
+	return fmt.Sprintf("seat already taken: %s", strings.Join(e.SeatCodes, ", "))
+}
+
 type OrderRepo struct {
 	db *pgxpool.Pool
 }
@@ -15,125 +69,750 @@ func NewOrderRepo(db *pgxpool.Pool) *OrderRepo {
 	return &OrderRepo{db: db}
 }
 
-func (or *OrderRepo) CreateOrder(ctx context.Context, order *models.Order, seatIDs []int) (*models.Order, error) {
+// CreateOrder membuat order pending dari seat hold yang masih berlaku, hold tersebut dihapus setelah dipakai.
+// Total harga dihitung ulang di dalam transaksi, serviceFee adalah biaya layanan per tiket
+// dan paymentWindow adalah batas waktu pembayaran sebelum order expired.
+func (or *OrderRepo) CreateOrder(ctx context.Context, order *models.Order, holdID int, seatIDs []int, serviceFee int, paymentWindow time.Duration) (*models.Order, error) {
 	tx, err := or.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback(ctx)
 
-	if order.QRCode == "" {
-		order.QRCode = "QR-CODE"
+	quote, err := prepareOrder(ctx, tx, order, holdID, seatIDs, serviceFee)
+	if err != nil {
+		return nil, err
 	}
+	order.Prices = quote.Items
+	order.TotalPrice = quote.Total
+	order.ServiceFee = quote.FeeTotal()
 
+	// qr_code diisi setelah order dan kursinya tersimpan, lihat issueTicket
+	order.QRCode = ""
+	order.Status = models.OrderPending
 	query := `
-		INSERT INTO orders (qr_code, users_id, schedules_id, payments_id, fullname, email, phone_number, created_at)
-		VALUES ($1,$2,$3,$4,$5,$6,$7,NOW())
-		RETURNING id, created_at
+		INSERT INTO orders (qr_code, users_id, schedules_id, payments_id, fullname, email, phone_number,
+		                    service_fee, total_price, status, expires_at, created_at)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,NOW() + make_interval(secs => $11),NOW())
+		RETURNING id, expires_at, created_at
 	`
 	err = tx.QueryRow(ctx, query,
 		order.QRCode, order.UserID, order.ScheduleID, order.PaymentID,
-		order.FullName, order.Email, order.Phone,
-	).Scan(&order.ID, &order.CreatedAt)
+		order.FullName, order.Email, order.Phone, order.ServiceFee, order.TotalPrice,
+		order.Status, paymentWindow.Seconds(),
+	).Scan(&order.ID, &order.ExpiresAt, &order.CreatedAt)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, seatID := range seatIDs {
-		_, err := tx.Exec(ctx, `INSERT INTO orders_seats (orders_id, seats_id) VALUES ($1,$2)`, order.ID, seatID)
+	for _, p := range quote.Items {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO orders_seats (orders_id, seats_id, base_price, category_surcharge, rule_adjustment, price)
+			VALUES ($1,$2,$3,$4,$5,$6)
+		`, order.ID, p.SeatID, p.BasePrice, p.CategorySurcharge, p.RuleAdjustment, p.Price)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	order.QRCode, err = issueTicket(ctx, tx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return nil, err
 	}
 	return order, nil
 }
 
+// IssueTicket membuat ulang token tiket, dipakai untuk order lama yang qr_code-nya belum berupa token
+func (or *OrderRepo) IssueTicket(ctx context.Context, orderID int) (string, error) {
+	tx, err := or.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	token, err := issueTicket(ctx, tx, orderID)
+	if err != nil {
+		return "", err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// issueTicket menandatangani token tiket (order, schedule, kursi, berlaku sampai film selesai) lalu menyimpannya di qr_code
+func issueTicket(ctx context.Context, tx pgx.Tx, orderID int) (string, error) {
+	claims := pkg.TicketClaims{OrderID: orderID}
+	err := tx.QueryRow(ctx, `
+		SELECT o.schedules_id,
+		       EXTRACT(EPOCH FROM (sc.date::date + t.time::time + make_interval(mins => m.duration))::timestamptz)::bigint
+		FROM orders o
+		INNER JOIN schedules sc ON sc.id = o.schedules_id
+		INNER JOIN times t ON t.id = sc.times_id
+		INNER JOIN movies m ON m.id = sc.movies_id
+		WHERE o.id = $1
+	`, orderID).Scan(&claims.ScheduleID, &claims.ExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrOrderNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT s.seat_code
+		FROM orders_seats os
+		INNER JOIN seats s ON s.id = os.seats_id
+		WHERE os.orders_id = $1
+		ORDER BY s.seat_code ASC
+	`, orderID)
+	if err != nil {
+		return "", err
+	}
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			rows.Close()
+			return "", err
+		}
+		claims.Seats = append(claims.Seats, code)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	token, err := pkg.SignTicket(claims)
+	if err != nil {
+		return "", err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE orders SET qr_code = $1 WHERE id = $2`, token, orderID); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// QuoteOrder menjalankan validasi CreateOrder tanpa me-lock schedule dan tanpa memakai hold,
+// jadi quote tidak pernah menahan checkout lain. Kursi tidak dicek ulang terhadap order lain
+// karena hold yang masih berlaku sudah menjamin kursinya, CreateOrder tetap memvalidasi semuanya lagi.
+func (or *OrderRepo) QuoteOrder(ctx context.Context, order *models.Order, holdID int, seatIDs []int, serviceFee int) (*models.OrderQuote, error) {
+	var exists bool
+	if err := or.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schedules WHERE id = $1)`, order.ScheduleID).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrScheduleNotFound
+	}
+
+	if err := checkPaymentMethod(ctx, or.db, order.PaymentID); err != nil {
+		return nil, err
+	}
+	if err := checkHold(ctx, or.db, holdID, order.UserID, order.ScheduleID, seatIDs, false); err != nil {
+		return nil, err
+	}
+
+	seatIDs = uniqueInts(seatIDs)
+	if err := checkScheduleSeats(ctx, or.db, order.ScheduleID, seatIDs); err != nil {
+		return nil, err
+	}
+
+	quote, err := priceSeats(ctx, or.db, order.ScheduleID, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+	quote.AddServiceFee(serviceFee)
+	return quote, nil
+}
+
+// prepareOrder me-lock schedule, memakai hold, memvalidasi kursi lalu menghitung harga order
+func prepareOrder(ctx context.Context, tx pgx.Tx, order *models.Order, holdID int, seatIDs []int, serviceFee int) (*models.OrderQuote, error) {
+	// lock baris schedule supaya checkout untuk schedule yang sama tidak saling balapan
+	var lockedID int
+	err := tx.QueryRow(ctx, `SELECT id FROM schedules WHERE id = $1 FOR UPDATE`, order.ScheduleID).Scan(&lockedID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkPaymentMethod(ctx, tx, order.PaymentID); err != nil {
+		return nil, err
+	}
+
+	if err := consumeHold(ctx, tx, holdID, order.UserID, order.ScheduleID, seatIDs); err != nil {
+		return nil, err
+	}
+
+	seatIDs = uniqueInts(seatIDs)
+	if err := checkScheduleSeats(ctx, tx, order.ScheduleID, seatIDs); err != nil {
+		return nil, err
+	}
+	if err := checkSeatsAvailable(ctx, tx, order.ScheduleID, seatIDs); err != nil {
+		return nil, err
+	}
+
+	quote, err := priceSeats(ctx, tx, order.ScheduleID, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+	quote.AddServiceFee(serviceFee)
+	return quote, nil
+}
+
+// checkSeatsAvailable mengecek kursi terhadap order dan hold aktif pada schedule yang sama.
+// Harus dipanggil di dalam transaksi yang sudah me-lock baris schedule.
+func checkSeatsAvailable(ctx context.Context, tx pgx.Tx, scheduleID int, seatIDs []int) error {
+	rows, err := tx.Query(ctx, `
+		SELECT s.seat_code
+		FROM seats s
+		WHERE s.id = ANY($2)
+		AND (
+			EXISTS (
+				SELECT 1
+				FROM orders_seats os
+				INNER JOIN orders o ON o.id = os.orders_id
+				WHERE o.schedules_id = $1 AND os.seats_id = s.id AND `+activeOrderFilter+`
+			) OR EXISTS (
+				SELECT 1
+				FROM seat_holds_seats hs
+				INNER JOIN seat_holds h ON h.id = hs.seat_holds_id
+				WHERE h.schedules_id = $1 AND h.expires_at > NOW() AND hs.seats_id = s.id
+			)
+		)
+		ORDER BY s.seat_code ASC
+	`, scheduleID, seatIDs)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var taken []string
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			return err
+		}
+		taken = append(taken, code)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(taken) > 0 {
+		return &SeatTakenError{SeatCodes: taken}
+	}
+	return nil
+}
+
 func (or *OrderRepo) GetOrderByID(ctx context.Context, id int) (*models.Order, error) {
 	var order models.Order
-	query := `
-		SELECT id, qr_code, users_id, schedules_id, payments_id,
-		       fullname, email, phone_number, created_at, updated_at
-		FROM orders WHERE id=$1
-	`
-	err := or.db.QueryRow(ctx, query, id).Scan(
-		&order.ID, &order.QRCode, &order.UserID, &order.ScheduleID,
-		&order.PaymentID, &order.FullName, &order.Email,
-		&order.Phone, &order.CreatedAt, &order.UpdatedAt,
-	)
+	err := scanOrder(or.db.QueryRow(ctx, `SELECT `+orderColumns+` FROM orders o WHERE o.id = $1`, id), &order)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrOrderNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	if err := loadOrderSeats(ctx, or.db, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// GetOrdersByUserID mengembalikan order milik user terbaru lebih dulu, pageSize 0 berarti tanpa pagination
+func (or *OrderRepo) GetOrdersByUserID(ctx context.Context, userID, page, pageSize int) ([]models.Order, error) {
+	offset := 0
+	if pageSize > 0 {
+		offset = (page - 1) * pageSize
+	}
 	rows, err := or.db.Query(ctx, `
-		SELECT s.id, s.seat_code
+		SELECT `+orderColumns+`
+		FROM orders o WHERE o.users_id = $1
+		ORDER BY o.created_at DESC, o.id DESC
+		LIMIT NULLIF($2, 0) OFFSET $3
+	`, userID, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []models.Order
+	for rows.Next() {
+		var order models.Order
+		if err := scanOrder(rows, &order); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range orders {
+		if err := loadOrderSeats(ctx, or.db, &orders[i]); err != nil {
+			return nil, err
+		}
+	}
+	return orders, nil
+}
+
+// loadOrderSeats mengisi kursi order beserta rincian harganya
+func loadOrderSeats(ctx context.Context, q querier, order *models.Order) error {
+	rows, err := q.Query(ctx, `
+		SELECT s.id, s.seat_code, s.row_code, s.column_number, s.category,
+		       os.base_price, os.category_surcharge, os.rule_adjustment, os.price
 		FROM seats s
 		INNER JOIN orders_seats os ON os.seats_id = s.id
 		WHERE os.orders_id = $1
-	`, id)
+		ORDER BY s.seat_code ASC
+	`, order.ID)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
+		var p models.SeatPrice
 		var seat models.Seat
-		if err := rows.Scan(&seat.ID, &seat.SeatCode); err != nil {
-			return nil, err
+		if err := rows.Scan(
+			&p.SeatID, &p.SeatCode, &seat.Row, &seat.Column, &p.Category,
+			&p.BasePrice, &p.CategorySurcharge, &p.RuleAdjustment, &p.Price,
+		); err != nil {
+			return err
 		}
+		seat.ID, seat.SeatCode, seat.Category = p.SeatID, p.SeatCode, p.Category
 		order.Seats = append(order.Seats, seat)
+		order.Prices = append(order.Prices, p)
 	}
+	return rows.Err()
+}
 
-	return &order, nil
+// UpdateOrderStatus memindahkan status order sesuai state machine dan mencatat waktu perpindahannya.
+// Order pending yang sudah lewat batas bayar hanya bisa dipindah ke expired.
+func (or *OrderRepo) UpdateOrderStatus(ctx context.Context, id int, next models.OrderStatus) error {
+	tx, err := or.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := transitionOrder(ctx, tx, id, next); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
-func (or *OrderRepo) GetOrdersByUserID(ctx context.Context, userID int) ([]models.Order, error) {
-	rows, err := or.db.Query(ctx, `
-		SELECT id, qr_code, users_id, schedules_id, payments_id,
-		       fullname, email, phone_number, created_at, updated_at
-		FROM orders WHERE users_id = $1
-		ORDER BY created_at DESC
-	`, userID)
+// transitionOrder dipakai di dalam transaksi lain yang juga mengubah status order (pembayaran, check-in, refund)
+func transitionOrder(ctx context.Context, tx pgx.Tx, id int, next models.OrderStatus) error {
+	var current models.OrderStatus
+	var overdue bool
+	err := tx.QueryRow(ctx, `
+		SELECT status, status = 'pending' AND expires_at <= NOW()
+		FROM orders WHERE id = $1
+		FOR UPDATE
+	`, id).Scan(&current, &overdue)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrOrderNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !current.CanTransitionTo(next) || (overdue && next != models.OrderExpired) {
+		return &OrderTransitionError{From: current, To: next}
+	}
+
+	// nama kolom timestamp diambil dari status yang sudah divalidasi, bukan dari input bebas
+	sql := fmt.Sprintf(`UPDATE orders SET status = $1, %s_at = NOW(), updated_at = NOW() WHERE id = $2`, next)
+	_, err = tx.Exec(ctx, sql, next, id)
+	return err
+}
+
+// ExpirePendingOrders mengubah order pending yang lewat batas bayar menjadi expired
+func (or *OrderRepo) ExpirePendingOrders(ctx context.Context) (int64, error) {
+	tag, err := or.db.Exec(ctx, `
+		UPDATE orders
+		SET status = 'expired', expired_at = NOW(), updated_at = NOW()
+		WHERE status = 'pending' AND expires_at <= NOW()
+	`)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// CheckIn memvalidasi tiket yang sudah diverifikasi signature-nya terhadap data order:
+// order harus paid, schedule tayang hari ini dan kursinya sama dengan isi tiket.
+// Kalau diterima semua kursi ditandai admitted dan order menjadi used.
+// Penolakan dikembalikan lewat CheckInResult.Reason, error hanya untuk kegagalan database.
+func (or *OrderRepo) CheckIn(ctx context.Context, ticket pkg.TicketClaims, staffID int) (*models.CheckInResult, error) {
+	tx, err := or.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	result := models.CheckInResult{OrderID: ticket.OrderID, ScheduleID: ticket.ScheduleID, Seats: ticket.Seats}
+
+	var status models.OrderStatus
+	var scheduleID int
+	var today bool
+	err = tx.QueryRow(ctx, `
+		SELECT o.status, o.schedules_id, m.title, sc.date::date::text, t.time::text, sc.date::date = CURRENT_DATE
+		FROM orders o
+		INNER JOIN schedules sc ON sc.id = o.schedules_id
+		INNER JOIN movies m ON m.id = sc.movies_id
+		INNER JOIN times t ON t.id = sc.times_id
+		WHERE o.id = $1
+		FOR UPDATE OF o
+	`, ticket.OrderID).Scan(&status, &scheduleID, &result.MovieTitle, &result.ShowDate, &result.ShowTime, &today)
+	if errors.Is(err, pgx.ErrNoRows) {
+		result.Reason = models.CheckInOrderNotFound
+		return &result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var seats []string
+	rows, err := tx.Query(ctx, `
+		SELECT s.seat_code
+		FROM orders_seats os
+		INNER JOIN seats s ON s.id = os.seats_id
+		WHERE os.orders_id = $1
+		ORDER BY s.seat_code ASC
+	`, ticket.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var code string
+		if err := rows.Scan(&code); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		seats = append(seats, code)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case scheduleID != ticket.ScheduleID || !slices.Equal(seats, ticket.Seats):
+		result.Reason = models.CheckInTicketMismatch
+	case status == models.OrderUsed:
+		result.Reason = models.CheckInAlreadyUsed
+	case status != models.OrderPaid:
+		result.Reason = models.CheckInNotPaid
+	case !today:
+		result.Reason = models.CheckInNotToday
+	}
+	if result.Reason != "" {
+		return &result, nil
+	}
+
+	var admittedAt time.Time
+	err = tx.QueryRow(ctx, `
+		UPDATE orders_seats SET admitted_at = NOW(), admitted_by = $2
+		WHERE orders_id = $1
+		RETURNING admitted_at
+	`, ticket.OrderID, staffID).Scan(&admittedAt)
 	if err != nil {
 		return nil, err
 	}
+	if err := transitionOrder(ctx, tx, ticket.OrderID, models.OrderUsed); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	result.Accepted = true
+	result.Reason = models.CheckInAccepted
+	result.AdmittedAt = &admittedAt
+	return &result, nil
+}
+
+// ===========================
+// admin
+
+// adminOrderSorts memetakan nilai query sort ke kolom, prefix "-" berarti descending
+var adminOrderSorts = map[string]string{
+	"id":          "o.id",
+	"created_at":  "o.created_at",
+	"total_price": "o.total_price",
+	"show_date":   "sc.date",
+	"status":      "o.status",
+}
+
+// adminOrderFrom dipakai bersama oleh daftar order admin dan hitungan totalnya
+const adminOrderFrom = `
+	FROM orders o
+	INNER JOIN users u ON u.id = o.users_id
+	INNER JOIN schedules sc ON sc.id = o.schedules_id
+	INNER JOIN movies m ON m.id = sc.movies_id
+	INNER JOIN cinemas c ON c.id = sc.cinemas_id
+	INNER JOIN locations l ON l.id = sc.locations_id
+	INNER JOIN times t ON t.id = sc.times_id
+	INNER JOIN payments p ON p.id = o.payments_id
+	WHERE TRUE
+`
+
+// adminOrderSelect adalah kolom AdminOrderSummary, urutannya sama dengan scanAdminOrder
+const adminOrderSelect = `
+	SELECT o.id, o.users_id, u.email, o.fullname, o.status, o.total_price,
+	       m.id, m.title, c.name, l.location, sc.date, t.time::text, p.name,
+	       COALESCE((
+	           SELECT array_agg(s.seat_code ORDER BY s.seat_code)
+	           FROM orders_seats os
+	           INNER JOIN seats s ON s.id = os.seats_id
+	           WHERE os.orders_id = o.id
+	       ), '{}'),
+	       o.created_at
+`
+
+func scanAdminOrder(row pgx.Row, o *models.AdminOrderSummary) error {
+	return row.Scan(
+		&o.ID, &o.UserID, &o.UserEmail, &o.FullName, &o.Status, &o.TotalPrice,
+		&o.MovieID, &o.MovieTitle, &o.CinemaName, &o.Location, &o.ShowDate, &o.ShowTime, &o.PaymentMethod,
+		&o.SeatCodes, &o.CreatedAt,
+	)
+}
+
+// adminOrderWhere menyusun kondisi WHERE dari filter, pagination dan sort tidak termasuk
+func adminOrderWhere(filter models.AdminOrderFilter) (string, []any) {
+	where := ""
+	var args []any
+	if filter.DateFrom != nil {
+		args = append(args, filter.DateFrom.Format("2006-01-02"))
+		where += fmt.Sprintf(" AND o.created_at::date >= $%d::date", len(args))
+	}
+	if filter.DateTo != nil {
+		args = append(args, filter.DateTo.Format("2006-01-02"))
+		where += fmt.Sprintf(" AND o.created_at::date <= $%d::date", len(args))
+	}
+	for _, f := range []struct {
+		column string
+		value  int
+	}{
+		{"sc.movies_id", filter.MovieID},
+		{"sc.cinemas_id", filter.CinemaID},
+		{"sc.locations_id", filter.LocationID},
+		{"o.payments_id", filter.PaymentID},
+	} {
+		if f.value > 0 {
+			args = append(args, f.value)
+			where += fmt.Sprintf(" AND %s = $%d", f.column, len(args))
+		}
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where += fmt.Sprintf(" AND o.status = $%d", len(args))
+	}
+	if filter.Email != "" {
+		args = append(args, "%"+filter.Email+"%")
+		where += fmt.Sprintf(" AND LOWER(u.email) LIKE LOWER($%d)", len(args))
+	}
+	return where, args
+}
+
+// adminOrderOrderBy menerjemahkan query sort, nama kolom diambil dari adminOrderSorts bukan dari input bebas
+func adminOrderOrderBy(sort string) (string, error) {
+	sort = cmp.Or(sort, "-created_at")
+	direction := "ASC"
+	if strings.HasPrefix(sort, "-") {
+		sort, direction = sort[1:], "DESC"
+	}
+	column, ok := adminOrderSorts[sort]
+	if !ok {
+		return "", ErrInvalidOrderSort
+	}
+	return fmt.Sprintf(" ORDER BY %s %s, o.id %s", column, direction, direction), nil
+}
+
+// GetOrdersForAdmin mengembalikan daftar order sesuai filter beserta total data sebelum pagination
+func (or *OrderRepo) GetOrdersForAdmin(ctx context.Context, filter models.AdminOrderFilter) ([]models.AdminOrderSummary, int, error) {
+	orderBy, err := adminOrderOrderBy(filter.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+	where, args := adminOrderWhere(filter)
+
+	var total int
+	if err := or.db.QueryRow(ctx, `SELECT COUNT(*)`+adminOrderFrom+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, filter.PageSize, (filter.Page-1)*filter.PageSize)
+	sql := adminOrderSelect + adminOrderFrom + where + orderBy + fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	rows, err := or.db.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, 0, err
+	}
 	defer rows.Close()
 
-	var orders []models.Order
+	orders := []models.AdminOrderSummary{}
 	for rows.Next() {
-		var order models.Order
+		var o models.AdminOrderSummary
+		if err := scanAdminOrder(rows, &o); err != nil {
+			return nil, 0, err
+		}
+		orders = append(orders, o)
+	}
+	return orders, total, rows.Err()
+}
+
+// StreamOrdersForAdmin memanggil fn untuk setiap order yang cocok dengan filter tanpa pagination.
+// Baris dibaca satu per satu dari database, jadi aman untuk export data besar.
+func (or *OrderRepo) StreamOrdersForAdmin(ctx context.Context, filter models.AdminOrderFilter, fn func(models.AdminOrderSummary) error) error {
+	orderBy, err := adminOrderOrderBy(filter.Sort)
+	if err != nil {
+		return err
+	}
+	where, args := adminOrderWhere(filter)
+
+	rows, err := or.db.Query(ctx, adminOrderSelect+adminOrderFrom+where+orderBy, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var o models.AdminOrderSummary
+		if err := scanAdminOrder(rows, &o); err != nil {
+			return err
+		}
+		if err := fn(o); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// StreamSeatManifest memanggil fn untuk setiap kursi schedule beserta order aktif yang menempatinya
+func (or *OrderRepo) StreamSeatManifest(ctx context.Context, scheduleID int, fn func(models.SeatManifestRow) error) error {
+	var exists bool
+	if err := or.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schedules WHERE id = $1)`, scheduleID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrScheduleNotFound
+	}
+
+	rows, err := or.db.Query(ctx, `
+		SELECT s.seat_code, COALESCE(s.row_code, ''), COALESCE(s.column_number, 0), s.category,
+		       CASE
+		           WHEN s.is_blocked THEN 'blocked'
+		           WHEN ao.id IS NOT NULL THEN 'sold'
+		           WHEN EXISTS (
+		               SELECT 1
+		               FROM seat_holds_seats hs
+		               INNER JOIN seat_holds h ON h.id = hs.seat_holds_id
+		               WHERE h.schedules_id = $1 AND h.expires_at > NOW() AND hs.seats_id = s.id
+		           ) THEN 'held'
+		           ELSE 'available'
+		       END,
+		       ao.id, ao.status, ao.fullname, ao.email, ao.phone_number, ao.price, ao.admitted_at
+		FROM seats s
+		LEFT JOIN LATERAL (
+		    SELECT o.id, o.status, o.fullname, o.email, o.phone_number, os.price, os.admitted_at
+		    FROM orders_seats os
+		    INNER JOIN orders o ON o.id = os.orders_id
+		    WHERE o.schedules_id = $1 AND os.seats_id = s.id AND `+activeOrderFilter+`
+		    LIMIT 1
+		) ao ON TRUE
+		WHERE `+scheduleSeatsFilter+`
+		ORDER BY s.row_code ASC, s.column_number ASC, s.seat_code ASC
+	`, scheduleID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r models.SeatManifestRow
 		if err := rows.Scan(
-			&order.ID, &order.QRCode, &order.UserID, &order.ScheduleID,
-			&order.PaymentID, &order.FullName, &order.Email,
-			&order.Phone, &order.CreatedAt, &order.UpdatedAt,
+			&r.SeatCode, &r.Row, &r.Column, &r.Category, &r.Status,
+			&r.OrderID, &r.OrderStatus, &r.FullName, &r.Email, &r.Phone, &r.Price, &r.AdmittedAt,
 		); err != nil {
-			return nil, err
+			return err
+		}
+		if err := fn(r); err != nil {
+			return err
 		}
+	}
+	return rows.Err()
+}
 
-		seatRows, err := or.db.Query(ctx, `
-			SELECT s.id, s.seat_code
-			FROM seats s
-			INNER JOIN orders_seats os ON os.seats_id = s.id
-			WHERE os.orders_id = $1
-		`, order.ID)
-		if err != nil {
+// GetOrderDetailForAdmin mengembalikan order beserta user, film, schedule, kursi, pembayaran dan refund-nya
+func (or *OrderRepo) GetOrderDetailForAdmin(ctx context.Context, id int) (*models.AdminOrderDetail, error) {
+	var detail models.AdminOrderDetail
+	sc := &detail.Schedule
+	err := or.db.QueryRow(ctx, `
+		SELECT u.email, m.id, m.title, m.poster_path, m.duration,
+		       sc.id, sc.movies_id, sc.date, COALESCE(NULLIF(sc.price, 0), c.base_price),
+		       c.id, c.name, c.base_price, l.id, l.location, t.id, t.time::text,
+		       p.id, p.name, p.logo_path, p.display_order, p.is_active
+	`+adminOrderFrom+` AND o.id = $1`, id).Scan(
+		&detail.UserEmail, &detail.Movie.ID, &detail.Movie.Title, &detail.Movie.Poster, &detail.Movie.Duration,
+		&sc.ID, &sc.MovieID, &sc.Date, &sc.Price,
+		&sc.Cinema.ID, &sc.Cinema.Name, &sc.Cinema.BasePrice, &sc.Location.ID, &sc.Location.Location, &sc.Time.ID, &sc.Time.Time,
+		&detail.PaymentMethod.ID, &detail.PaymentMethod.Name, &detail.PaymentMethod.LogoPath,
+		&detail.PaymentMethod.DisplayOrder, &detail.PaymentMethod.IsActive,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := scanOrder(or.db.QueryRow(ctx, `SELECT `+orderColumns+` FROM orders o WHERE o.id = $1`, id), &detail.Order); err != nil {
+		return nil, err
+	}
+	if err := loadOrderSeats(ctx, or.db, &detail.Order); err != nil {
+		return nil, err
+	}
+
+	detail.Payments = []models.PaymentIntent{}
+	rows, err := or.db.Query(ctx, `SELECT `+paymentIntentColumns+` FROM payment_intents WHERE orders_id = $1 ORDER BY id ASC`, id)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var intent models.PaymentIntent
+		if err := scanPaymentIntent(rows, &intent); err != nil {
+			rows.Close()
 			return nil, err
 		}
-		defer seatRows.Close()
-
-		for seatRows.Next() {
-			var seat models.Seat
-			if err := seatRows.Scan(&seat.ID, &seat.SeatCode); err != nil {
-				return nil, err
-			}
-			order.Seats = append(order.Seats, seat)
-		}
-
-		orders = append(orders, order)
+		detail.Payments = append(detail.Payments, intent)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	return orders, nil
+	detail.Refunds = []models.Refund{}
+	rows, err = or.db.Query(ctx, `SELECT `+refundColumns+` FROM refunds WHERE orders_id = $1 ORDER BY id ASC`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var refund models.Refund
+		if err := scanRefund(rows, &refund); err != nil {
+			return nil, err
+		}
+		detail.Refunds = append(detail.Refunds, refund)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &detail, nil
 }
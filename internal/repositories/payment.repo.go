@@ -0,0 +1,411 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrPaymentIntentNotFound  = errors.New("payment intent not found")
+	ErrPaymentMethodNotFound  = errors.New("payment method not found")
+	ErrPaymentMethodInactive  = errors.New("payment method is not available")
+	ErrPaymentMethodHasOrders = errors.New("payment method is used by orders")
+	ErrCancellationClosed     = errors.New("cancellation window has closed")
+)
+
+// paymentIntentTransitions mencegah webhook yang datang terlambat / berulang memundurkan status pembayaran
+var paymentIntentTransitions = map[string][]string{
+	"pending":   {"succeeded", "failed"},
+	"failed":    {"succeeded"},
+	"succeeded": {"refunded"},
+}
+
+const paymentIntentColumns = `id, orders_id, provider, charge_id, amount, checkout_url, status, created_at, updated_at`
+
+func scanPaymentIntent(row pgx.Row, intent *models.PaymentIntent) error {
+	return row.Scan(
+		&intent.ID, &intent.OrderID, &intent.Provider, &intent.ChargeID, &intent.Amount,
+		&intent.CheckoutURL, &intent.Status, &intent.CreatedAt, &intent.UpdatedAt,
+	)
+}
+
+const refundColumns = `id, orders_id, payment_intents_id, amount, percent, reason, is_forced, status, requested_by, created_at, updated_at`
+
+func scanRefund(row pgx.Row, refund *models.Refund) error {
+	return row.Scan(
+		&refund.ID, &refund.OrderID, &refund.PaymentIntentID, &refund.Amount, &refund.Percent, &refund.Reason,
+		&refund.Forced, &refund.Status, &refund.RequestedBy, &refund.CreatedAt, &refund.UpdatedAt,
+	)
+}
+
+type PaymentRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPaymentRepo(db *pgxpool.Pool) *PaymentRepo {
+	return &PaymentRepo{db: db}
+}
+
+// GetPendingIntent mengembalikan payment intent pending terakhir milik order
+func (pr *PaymentRepo) GetPendingIntent(ctx context.Context, orderID int) (*models.PaymentIntent, error) {
+	var intent models.PaymentIntent
+	err := scanPaymentIntent(pr.db.QueryRow(ctx, `
+		SELECT `+paymentIntentColumns+`
+		FROM payment_intents
+		WHERE orders_id = $1 AND status = 'pending'
+		ORDER BY id DESC
+		LIMIT 1
+	`, orderID), &intent)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPaymentIntentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &intent, nil
+}
+
+// GetIntentByChargeID mengembalikan payment intent berdasarkan charge id dari provider
+func (pr *PaymentRepo) GetIntentByChargeID(ctx context.Context, chargeID string) (*models.PaymentIntent, error) {
+	var intent models.PaymentIntent
+	err := scanPaymentIntent(pr.db.QueryRow(ctx, `
+		SELECT `+paymentIntentColumns+` FROM payment_intents WHERE charge_id = $1
+	`, chargeID), &intent)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrPaymentIntentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &intent, nil
+}
+
+func (pr *PaymentRepo) CreateIntent(ctx context.Context, intent *models.PaymentIntent) error {
+	return pr.db.QueryRow(ctx, `
+		INSERT INTO payment_intents (orders_id, provider, charge_id, amount, checkout_url, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		RETURNING id, created_at
+	`, intent.OrderID, intent.Provider, intent.ChargeID, intent.Amount, intent.CheckoutURL, intent.Status,
+	).Scan(&intent.ID, &intent.CreatedAt)
+}
+
+// ApplyPaymentEvent mencatat status pembayaran dari webhook lalu memindahkan status order.
+// Event yang sama boleh datang berkali-kali. Kalau pembayaran berhasil tapi order sudah tidak bisa dibayar
+// (misalnya sudah expired), status intent tetap disimpan, refund 100% dicatat di transaksi yang sama
+// lalu OrderTransitionError dikembalikan supaya pemanggil meneruskan refund ke provider dan memanggil CompleteRefund.
+func (pr *PaymentRepo) ApplyPaymentEvent(ctx context.Context, chargeID, status string) (*models.PaymentIntent, *models.Refund, error) {
+	tx, err := pr.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var intent models.PaymentIntent
+	err = scanPaymentIntent(tx.QueryRow(ctx, `
+		SELECT `+paymentIntentColumns+` FROM payment_intents WHERE charge_id = $1 FOR UPDATE
+	`, chargeID), &intent)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, ErrPaymentIntentNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	allowed := false
+	for _, next := range paymentIntentTransitions[intent.Status] {
+		if next == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		// event duplikat atau terlambat, cukup diabaikan
+		return &intent, nil, nil
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE payment_intents SET status = $1, updated_at = NOW() WHERE id = $2 RETURNING updated_at
+	`, status, intent.ID).Scan(&intent.UpdatedAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	intent.Status = status
+
+	var transitionErr error
+	switch status {
+	case "succeeded":
+		transitionErr = transitionOrder(ctx, tx, intent.OrderID, models.OrderPaid)
+	case "refunded":
+		transitionErr = transitionOrder(ctx, tx, intent.OrderID, models.OrderRefunded)
+	}
+	var stateErr *OrderTransitionError
+	if transitionErr != nil && !errors.As(transitionErr, &stateErr) {
+		return nil, nil, transitionErr
+	}
+
+	// dana sudah masuk tapi order tidak bisa dibayar lagi, kewajiban refund harus tercatat
+	// walaupun refund ke provider nanti gagal
+	var refund *models.Refund
+	if transitionErr != nil && status == "succeeded" && intent.Amount > 0 {
+		refund = &models.Refund{
+			OrderID:         intent.OrderID,
+			PaymentIntentID: &intent.ID,
+			ChargeID:        intent.ChargeID,
+			Amount:          intent.Amount,
+			Percent:         100,
+			Reason:          "order can no longer be paid",
+			Status:          models.RefundPending,
+		}
+		err = tx.QueryRow(ctx, `
+			INSERT INTO refunds (orders_id, payment_intents_id, amount, percent, reason, is_forced, status, created_at)
+			VALUES ($1, $2, $3, $4, $5, FALSE, $6, NOW())
+			RETURNING id, created_at
+		`, refund.OrderID, refund.PaymentIntentID, refund.Amount, refund.Percent, refund.Reason, refund.Status,
+		).Scan(&refund.ID, &refund.CreatedAt)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, err
+	}
+	return &intent, refund, transitionErr
+}
+
+// ===========================
+// cancellation & refunds
+
+// CancelOrder membatalkan order lalu mencatat refund kalau order sudah dibayar.
+// Order pending dibatalkan tanpa refund. Order paid hanya bisa dibatalkan customer selama masih masuk
+// cancellation policy, sedangkan force (admin) melewati policy dan selalu mengembalikan 100%.
+// Kursi otomatis lepas karena order cancelled tidak lagi termasuk activeOrderFilter.
+// Dana belum dikembalikan di sini, pemanggil meneruskannya ke provider lalu memanggil CompleteRefund.
+func (pr *PaymentRepo) CancelOrder(ctx context.Context, id int, policy models.CancellationPolicy, cancelledBy int, reason string, force bool) (*models.Order, *models.Refund, error) {
+	tx, err := pr.db.Begin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var status models.OrderStatus
+	var totalPrice int
+	var untilShow float64
+	err = tx.QueryRow(ctx, `
+		SELECT o.status, o.total_price,
+		       EXTRACT(EPOCH FROM (sc.date::date + t.time::time)::timestamptz - NOW())::float8
+		FROM orders o
+		INNER JOIN schedules sc ON sc.id = o.schedules_id
+		INNER JOIN times t ON t.id = sc.times_id
+		WHERE o.id = $1
+		FOR UPDATE OF o
+	`, id).Scan(&status, &totalPrice, &untilShow)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	percent := 100
+	if status == models.OrderPaid && !force {
+		var ok bool
+		percent, ok = policy.RefundPercent(time.Duration(untilShow * float64(time.Second)))
+		if !ok {
+			return nil, nil, ErrCancellationClosed
+		}
+	}
+
+	if err := transitionOrder(ctx, tx, id, models.OrderCancelled); err != nil {
+		return nil, nil, err
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE orders SET cancel_reason = NULLIF($1, ''), cancelled_by = $2 WHERE id = $3
+	`, reason, cancelledBy, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var refund *models.Refund
+	if status == models.OrderPaid {
+		refund, err = createRefund(ctx, tx, id, totalPrice, percent, cancelledBy, reason, force)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var order models.Order
+	if err := scanOrder(tx.QueryRow(ctx, `SELECT `+orderColumns+` FROM orders o WHERE o.id = $1`, id), &order); err != nil {
+		return nil, nil, err
+	}
+	if err := loadOrderSeats(ctx, tx, &order); err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, nil, err
+	}
+	return &order, refund, nil
+}
+
+// createRefund mencatat refund pending sebesar percent dari pembayaran yang berhasil.
+// Order lama tanpa payment intent memakai total_price dan refund-nya diproses manual.
+// Refund 0 rupiah tidak dicatat.
+func createRefund(ctx context.Context, tx pgx.Tx, orderID, totalPrice, percent, requestedBy int, reason string, force bool) (*models.Refund, error) {
+	refund := models.Refund{
+		OrderID:     orderID,
+		Percent:     percent,
+		Reason:      reason,
+		Forced:      force,
+		Status:      models.RefundPending,
+		RequestedBy: &requestedBy,
+	}
+
+	paid := totalPrice
+	var intent models.PaymentIntent
+	err := scanPaymentIntent(tx.QueryRow(ctx, `
+		SELECT `+paymentIntentColumns+`
+		FROM payment_intents
+		WHERE orders_id = $1 AND status = 'succeeded'
+		ORDER BY id DESC
+		LIMIT 1
+		FOR UPDATE
+	`, orderID), &intent)
+	switch {
+	case err == nil:
+		paid = intent.Amount
+		refund.PaymentIntentID = &intent.ID
+		refund.ChargeID = intent.ChargeID
+	case !errors.Is(err, pgx.ErrNoRows):
+		return nil, err
+	}
+
+	refund.Amount = paid * percent / 100
+	if refund.Amount <= 0 {
+		return nil, nil
+	}
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO refunds (orders_id, payment_intents_id, amount, percent, reason, is_forced, status, requested_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		RETURNING id, created_at
+	`, refund.OrderID, refund.PaymentIntentID, refund.Amount, refund.Percent, refund.Reason, refund.Forced, refund.Status, refund.RequestedBy,
+	).Scan(&refund.ID, &refund.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+// CompleteRefund menyimpan hasil refund dari provider. Refund penuh juga menandai payment intent sebagai refunded
+// supaya webhook refunded yang datang belakangan diabaikan.
+func (pr *PaymentRepo) CompleteRefund(ctx context.Context, refund *models.Refund, status models.RefundStatus) error {
+	tx, err := pr.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		UPDATE refunds SET status = $1, updated_at = NOW() WHERE id = $2 RETURNING updated_at
+	`, status, refund.ID).Scan(&refund.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	refund.Status = status
+
+	if status == models.RefundSucceeded && refund.Percent == 100 && refund.PaymentIntentID != nil {
+		_, err = tx.Exec(ctx, `
+			UPDATE payment_intents SET status = 'refunded', updated_at = NOW() WHERE id = $1 AND status = 'succeeded'
+		`, *refund.PaymentIntentID)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// ===========================
+// payment methods
+
+// GetPaymentMethods mengembalikan metode pembayaran urut display_order, activeOnly untuk tampilan customer
+func (pr *PaymentRepo) GetPaymentMethods(ctx context.Context, activeOnly bool) ([]models.PaymentMethod, error) {
+	rows, err := pr.db.Query(ctx, `
+		SELECT id, name, logo_path, display_order, is_active
+		FROM payments
+		WHERE is_active OR NOT $1
+		ORDER BY display_order ASC, id ASC
+	`, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	methods := []models.PaymentMethod{}
+	for rows.Next() {
+		var m models.PaymentMethod
+		if err := rows.Scan(&m.ID, &m.Name, &m.LogoPath, &m.DisplayOrder, &m.IsActive); err != nil {
+			return nil, err
+		}
+		methods = append(methods, m)
+	}
+	return methods, rows.Err()
+}
+
+func (pr *PaymentRepo) CreatePaymentMethod(ctx context.Context, method *models.PaymentMethod) error {
+	return pr.db.QueryRow(ctx, `
+		INSERT INTO payments (name, logo_path, display_order, is_active)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`, method.Name, method.LogoPath, method.DisplayOrder, method.IsActive).Scan(&method.ID)
+}
+
+func (pr *PaymentRepo) UpdatePaymentMethod(ctx context.Context, method models.PaymentMethod) error {
+	tag, err := pr.db.Exec(ctx, `
+		UPDATE payments
+		SET name = $1, logo_path = $2, display_order = $3, is_active = $4
+		WHERE id = $5
+	`, method.Name, method.LogoPath, method.DisplayOrder, method.IsActive, method.ID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPaymentMethodNotFound
+	}
+	return nil
+}
+
+// DeletePaymentMethod ditolak kalau sudah dipakai order, metode seperti itu cukup dinonaktifkan
+func (pr *PaymentRepo) DeletePaymentMethod(ctx context.Context, id int) error {
+	var used bool
+	if err := pr.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM orders WHERE payments_id = $1)`, id).Scan(&used); err != nil {
+		return err
+	}
+	if used {
+		return ErrPaymentMethodHasOrders
+	}
+
+	tag, err := pr.db.Exec(ctx, `DELETE FROM payments WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPaymentMethodNotFound
+	}
+	return nil
+}
+
+// checkPaymentMethod memastikan metode pembayaran order ada dan masih aktif
+func checkPaymentMethod(ctx context.Context, q querier, id int) error {
+	var active bool
+	err := q.QueryRow(ctx, `SELECT is_active FROM payments WHERE id = $1`, id).Scan(&active)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !active) {
+		return ErrPaymentMethodInactive
+	}
+	return err
+}
@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrScheduleUnpriced = errors.New("schedule and cinema have no base price")
+
+// querier dipenuhi oleh *pgxpool.Pool maupun pgx.Tx, supaya perhitungan harga
+// bisa dipakai di dalam transaksi order maupun di luar transaksi.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// priceSeats mengambil data harga schedule dari database lalu menghitungnya dengan models.PriceSeats:
+// harga dasar (schedules.price, fallback ke cinemas.base_price) + surcharge kategori kursi
+// + pricing_rules yang cocok dengan hari dan jam tayang.
+func priceSeats(ctx context.Context, q querier, scheduleID int, seatIDs []int) (*models.OrderQuote, error) {
+	var basePrice int
+	var dayType, showTime string
+	err := q.QueryRow(ctx, `
+		SELECT COALESCE(NULLIF(sc.price, 0), c.base_price),
+		       CASE WHEN EXTRACT(ISODOW FROM sc.date) IN (6, 7) THEN 'weekend' ELSE 'weekday' END,
+		       t.time::time::text
+		FROM schedules sc
+		INNER JOIN cinemas c ON c.id = sc.cinemas_id
+		INNER JOIN times t ON t.id = sc.times_id
+		WHERE sc.id = $1
+	`, scheduleID).Scan(&basePrice, &dayType, &showTime)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if basePrice <= 0 {
+		return nil, ErrScheduleUnpriced
+	}
+
+	rules, err := matchPricingRules(ctx, q, dayType, showTime)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, `
+		SELECT s.id, s.seat_code, s.category, COALESCE(cp.surcharge, 0)
+		FROM seats s
+		LEFT JOIN seat_category_prices cp ON cp.category = s.category
+		WHERE s.id = ANY($1)
+		ORDER BY s.seat_code ASC
+	`, seatIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var seats []models.SeatPrice
+	for rows.Next() {
+		var p models.SeatPrice
+		if err := rows.Scan(&p.SeatID, &p.SeatCode, &p.Category, &p.CategorySurcharge); err != nil {
+			return nil, err
+		}
+		seats = append(seats, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(seats) != len(seatIDs) {
+		return nil, ErrInvalidSeat
+	}
+
+	quote := models.PriceSeats(basePrice, seats, rules)
+	quote.ScheduleID = scheduleID
+	return &quote, nil
+}
+
+// matchPricingRules mengambil pricing_rules aktif yang berlaku untuk hari dan jam tayang.
+// time_from > time_to berarti rentang jam melewati tengah malam.
+func matchPricingRules(ctx context.Context, q querier, dayType, showTime string) ([]models.PriceAdjustment, error) {
+	rows, err := q.Query(ctx, `
+		SELECT r.name, r.adjustment
+		FROM pricing_rules r
+		WHERE r.is_active
+		AND (r.day_type IS NULL OR r.day_type = $1)
+		AND CASE
+			WHEN r.time_from IS NULL AND r.time_to IS NULL THEN TRUE
+			WHEN r.time_from IS NULL THEN $2::time < r.time_to
+			WHEN r.time_to IS NULL THEN $2::time >= r.time_from
+			WHEN r.time_from <= r.time_to THEN $2::time >= r.time_from AND $2::time < r.time_to
+			ELSE $2::time >= r.time_from OR $2::time < r.time_to
+		END
+		ORDER BY r.id ASC
+	`, dayType, showTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []models.PriceAdjustment
+	for rows.Next() {
+		var rule models.PriceAdjustment
+		if err := rows.Scan(&rule.Name, &rule.Amount); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
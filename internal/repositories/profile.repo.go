@@ -2,11 +2,15 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var ErrProfileNotFound = errors.New("profile not found")
+
 type ProfileRepo struct {
 	db *pgxpool.Pool
 }
@@ -18,11 +22,11 @@ func NewProfileRepo(db *pgxpool.Pool) *ProfileRepo {
 func (pr *ProfileRepo) GetProfile(ctx context.Context, userID int) (*models.Profile, error) {
 	var p models.Profile
 	query := `
-		SELECT user_id, firstname, lastname, phone_number
+		SELECT user_id, firstname, lastname, phone_number, avatar
 		FROM profile WHERE user_id = $1
 	`
 	err := pr.db.QueryRow(ctx, query, userID).
-		Scan(&p.UserID, &p.FirstName, &p.LastName, &p.PhoneNumber)
+		Scan(&p.UserID, &p.FirstName, &p.LastName, &p.PhoneNumber, &p.AvatarURL)
 	if err != nil {
 		return nil, err
 	}
@@ -43,3 +47,30 @@ func (pr *ProfileRepo) UpdateProfile(ctx context.Context, profile models.Profile
 	)
 	return err
 }
+
+// UpdateAvatar mengganti avatar user dan mengembalikan path avatar sebelumnya (nil kalau belum ada)
+// supaya pemanggil bisa menghapus file lamanya
+func (pr *ProfileRepo) UpdateAvatar(ctx context.Context, userID int, path string) (*string, error) {
+	tx, err := pr.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var old *string
+	err = tx.QueryRow(ctx, `SELECT avatar FROM profile WHERE user_id = $1 FOR UPDATE`, userID).Scan(&old)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrProfileNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE profile SET avatar = $1 WHERE user_id = $2`, path, userID); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return old, nil
+}
@@ -0,0 +1,207 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrScheduleHasOrders = errors.New("schedule already has orders")
+
+// ScheduleConflictError dikembalikan kalau jadwal baru bentrok dengan jadwal lain di cinema dan lokasi yang sama
+type ScheduleConflictError struct {
+	ScheduleID int
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return fmt.Sprintf("overlaps with schedule %d in the same cinema", e.ScheduleID)
+}
+
+type ScheduleRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewScheduleRepo(db *pgxpool.Pool) *ScheduleRepo {
+	return &ScheduleRepo{db: db}
+}
+
+func (sr *ScheduleRepo) GetScheduleByID(ctx context.Context, id int) (*models.Schedule, error) {
+	sql := `
+		SELECT id, movies_id, cinemas_id, times_id, locations_id, date, price
+		FROM schedules
+		WHERE id = $1
+	`
+	var s models.Schedule
+	err := sr.db.QueryRow(ctx, sql, id).Scan(&s.ID, &s.MovieID, &s.CinemaID, &s.TimeID, &s.LocationID, &s.Date, &s.Price)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (sr *ScheduleRepo) CreateSchedule(ctx context.Context, schedule models.Schedule) (int, error) {
+	tx, err := sr.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := validateSchedule(ctx, tx, schedule); err != nil {
+		return 0, err
+	}
+
+	sql := `
+		INSERT INTO schedules (movies_id, cinemas_id, times_id, locations_id, date, price)
+		VALUES ($1, $2, $3, $4, $5::date, $6)
+		RETURNING id
+	`
+	var id int
+	err = tx.QueryRow(ctx, sql,
+		schedule.MovieID, schedule.CinemaID, schedule.TimeID, schedule.LocationID,
+		schedule.Date.Format("2006-01-02"), schedule.Price,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (sr *ScheduleRepo) UpdateSchedule(ctx context.Context, schedule models.Schedule) error {
+	tx, err := sr.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var current models.Schedule
+	err = tx.QueryRow(ctx, `
+		SELECT movies_id, cinemas_id, times_id, locations_id, date
+		FROM schedules WHERE id = $1 FOR UPDATE
+	`, schedule.ID).Scan(&current.MovieID, &current.CinemaID, &current.TimeID, &current.LocationID, &current.Date)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrScheduleNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	// setelah ada order hanya harga yang boleh berubah, tiket yang sudah terjual tidak boleh pindah penayangan
+	moved := current.MovieID != schedule.MovieID || current.CinemaID != schedule.CinemaID ||
+		current.TimeID != schedule.TimeID || current.LocationID != schedule.LocationID ||
+		current.Date.Format("2006-01-02") != schedule.Date.Format("2006-01-02")
+	if moved {
+		var hasOrders bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM orders WHERE schedules_id = $1)`, schedule.ID).Scan(&hasOrders); err != nil {
+			return err
+		}
+		if hasOrders {
+			return ErrScheduleHasOrders
+		}
+	}
+
+	if err := validateSchedule(ctx, tx, schedule); err != nil {
+		return err
+	}
+
+	sql := `
+		UPDATE schedules
+		SET movies_id = $1, cinemas_id = $2, times_id = $3, locations_id = $4, date = $5::date, price = $6
+		WHERE id = $7
+	`
+	_, err = tx.Exec(ctx, sql,
+		schedule.MovieID, schedule.CinemaID, schedule.TimeID, schedule.LocationID,
+		schedule.Date.Format("2006-01-02"), schedule.Price, schedule.ID,
+	)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (sr *ScheduleRepo) DeleteSchedule(ctx context.Context, id int) error {
+	tx, err := sr.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var hasOrders bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM orders WHERE schedules_id = $1)`, id).Scan(&hasOrders); err != nil {
+		return err
+	}
+	if hasOrders {
+		return ErrScheduleHasOrders
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM schedules WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrScheduleNotFound
+	}
+	return tx.Commit(ctx)
+}
+
+// validateSchedule mengecek semua relasi schedule lalu memastikan tidak ada penayangan lain
+// di cinema dan lokasi yang sama yang waktunya beririsan, berdasarkan durasi movie.
+func validateSchedule(ctx context.Context, tx pgx.Tx, schedule models.Schedule) error {
+	if err := checkReferences(ctx, tx, "movies", "movie", []int{schedule.MovieID}); err != nil {
+		return err
+	}
+	if err := checkReferences(ctx, tx, "cinemas", "cinema", []int{schedule.CinemaID}); err != nil {
+		return err
+	}
+	if err := checkReferences(ctx, tx, "locations", "location", []int{schedule.LocationID}); err != nil {
+		return err
+	}
+	if err := checkReferences(ctx, tx, "times", "time", []int{schedule.TimeID}); err != nil {
+		return err
+	}
+
+	// lock cinema supaya dua admin tidak bisa membuat jadwal bentrok secara bersamaan
+	var lockedID int
+	if err := tx.QueryRow(ctx, `SELECT id FROM cinemas WHERE id = $1 FOR UPDATE`, schedule.CinemaID).Scan(&lockedID); err != nil {
+		return err
+	}
+
+	var conflictID int
+	err := tx.QueryRow(ctx, `
+		WITH new_screening AS (
+			SELECT $3::date + t.time::time AS starts_at,
+			       $3::date + t.time::time + make_interval(mins => m.duration) AS ends_at
+			FROM times t, movies m
+			WHERE t.id = $4 AND m.id = $5
+		)
+		SELECT s.id
+		FROM schedules s
+		INNER JOIN times t ON t.id = s.times_id
+		INNER JOIN movies m ON m.id = s.movies_id
+		CROSS JOIN new_screening n
+		WHERE s.cinemas_id = $1 AND s.locations_id = $2 AND s.id <> $6
+		AND s.date::date + t.time::time < n.ends_at
+		AND s.date::date + t.time::time + make_interval(mins => m.duration) > n.starts_at
+		ORDER BY s.id ASC
+		LIMIT 1
+	`, schedule.CinemaID, schedule.LocationID, schedule.Date.Format("2006-01-02"),
+		schedule.TimeID, schedule.MovieID, schedule.ID,
+	).Scan(&conflictID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return &ScheduleConflictError{ScheduleID: conflictID}
+}
@@ -0,0 +1,257 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrRefreshTokenInvalid = errors.New("refresh token is invalid or expired")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used")
+)
+
+// revocationCache menyimpan salinan daftar pencabutan token di memory supaya VerifyToken
+// tidak perlu query ke database di setiap request. Cache dimuat ulang dari Postgres
+// setiap revocationRefreshInterval, dan langsung diperbarui saat pencabutan lewat TokenRepo yang sama,
+// karena itu satu TokenRepo dipakai bersama oleh middleware dan handler.
+type revocationCache struct {
+	mu       sync.RWMutex
+	tokens   map[string]time.Time // jti -> waktu expired token
+	users    map[int]time.Time    // user id -> token yang terbit sebelum waktu ini tidak berlaku
+	loadedAt time.Time
+}
+
+const revocationRefreshInterval = 30 * time.Second
+
+type TokenRepo struct {
+	db          *pgxpool.Pool
+	revocations *revocationCache
+}
+
+func NewTokenRepo(db *pgxpool.Pool) *TokenRepo {
+	return &TokenRepo{db: db, revocations: &revocationCache{}}
+}
+
+func (tr *TokenRepo) CreateRefreshToken(ctx context.Context, userID int, familyID, tokenHash string, ttl time.Duration) error {
+	sql := `
+		INSERT INTO refresh_tokens (users_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, NOW() + make_interval(secs => $4), NOW())
+	`
+	_, err := tr.db.Exec(ctx, sql, userID, familyID, tokenHash, ttl.Seconds())
+	return err
+}
+
+// RotateRefreshToken menandai refresh token lama sebagai terpakai dan menyimpan token baru
+// di family yang sama. Kalau token lama ternyata sudah pernah dipakai (reuse), seluruh
+// family dicabut dan ErrRefreshTokenReused dikembalikan.
+func (tr *TokenRepo) RotateRefreshToken(ctx context.Context, oldHash, newHash string, ttl time.Duration) (int, error) {
+	tx, err := tr.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var (
+		id       int
+		userID   int
+		familyID string
+		expired  bool
+		used     bool
+	)
+	err = tx.QueryRow(ctx, `
+		SELECT id, users_id, family_id, expires_at <= NOW(), (used_at IS NOT NULL OR revoked_at IS NOT NULL)
+		FROM refresh_tokens
+		WHERE token_hash = $1
+		FOR UPDATE
+	`, oldHash).Scan(&id, &userID, &familyID, &expired, &used)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrRefreshTokenInvalid
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if used {
+		_, err := tx.Exec(ctx, `
+			UPDATE refresh_tokens SET revoked_at = NOW()
+			WHERE family_id = $1 AND revoked_at IS NULL
+		`, familyID)
+		if err != nil {
+			return 0, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return 0, err
+		}
+		return 0, ErrRefreshTokenReused
+	}
+	if expired {
+		return 0, ErrRefreshTokenInvalid
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE refresh_tokens SET used_at = NOW() WHERE id = $1`, id); err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO refresh_tokens (users_id, family_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, NOW() + make_interval(secs => $4), NOW())
+	`, userID, familyID, newHash, ttl.Seconds())
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return userID, nil
+}
+
+// RevokeRefreshTokenFamily mencabut refresh token beserta seluruh family hasil rotasinya
+func (tr *TokenRepo) RevokeRefreshTokenFamily(ctx context.Context, tokenHash string) error {
+	sql := `
+		UPDATE refresh_tokens SET revoked_at = NOW()
+		WHERE revoked_at IS NULL
+		AND family_id = (SELECT family_id FROM refresh_tokens WHERE token_hash = $1)
+	`
+	_, err := tr.db.Exec(ctx, sql, tokenHash)
+	return err
+}
+
+func (tr *TokenRepo) RevokeAccessToken(ctx context.Context, jti string, userID int, expiresAt time.Time) error {
+	sql := `
+		INSERT INTO revoked_tokens (jti, users_id, expires_at, revoked_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (jti) DO NOTHING
+	`
+	if _, err := tr.db.Exec(ctx, sql, jti, userID, expiresAt); err != nil {
+		return err
+	}
+
+	tr.revocations.mu.Lock()
+	if tr.revocations.tokens == nil {
+		tr.revocations.tokens = make(map[string]time.Time)
+	}
+	tr.revocations.tokens[jti] = expiresAt
+	tr.revocations.mu.Unlock()
+	return nil
+}
+
+// RevokeUserSessions mencabut semua access token dan refresh token milik user
+func (tr *TokenRepo) RevokeUserSessions(ctx context.Context, userID int) error {
+	tx, err := tr.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// dibulatkan ke detik karena claim iat hanya punya presisi detik
+	var revokedAt time.Time
+	err = tx.QueryRow(ctx, `
+		INSERT INTO user_session_revocations (users_id, revoked_at)
+		VALUES ($1, date_trunc('second', NOW()))
+		ON CONFLICT (users_id) DO UPDATE SET revoked_at = EXCLUDED.revoked_at
+		RETURNING revoked_at
+	`, userID).Scan(&revokedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE refresh_tokens SET revoked_at = NOW()
+		WHERE users_id = $1 AND revoked_at IS NULL
+	`, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	tr.revocations.mu.Lock()
+	if tr.revocations.users == nil {
+		tr.revocations.users = make(map[int]time.Time)
+	}
+	tr.revocations.users[userID] = revokedAt
+	tr.revocations.mu.Unlock()
+	return nil
+}
+
+// IsAccessTokenRevoked mengecek apakah token dengan jti dan waktu terbit tersebut sudah dicabut
+func (tr *TokenRepo) IsAccessTokenRevoked(ctx context.Context, jti string, userID int, issuedAt time.Time) (bool, error) {
+	tr.revocations.mu.RLock()
+	stale := time.Since(tr.revocations.loadedAt) > revocationRefreshInterval
+	tr.revocations.mu.RUnlock()
+
+	if stale {
+		if err := tr.loadRevocations(ctx); err != nil {
+			return false, err
+		}
+	}
+
+	tr.revocations.mu.RLock()
+	defer tr.revocations.mu.RUnlock()
+
+	if _, ok := tr.revocations.tokens[jti]; ok {
+		return true, nil
+	}
+	// iat hanya presisi detik, token yang terbit di detik yang sama dengan pencabutan ikut dicabut
+	if revokedAt, ok := tr.revocations.users[userID]; ok && !issuedAt.After(revokedAt) {
+		return true, nil
+	}
+	return false, nil
+}
+
+func (tr *TokenRepo) loadRevocations(ctx context.Context) error {
+	tokens := make(map[string]time.Time)
+	rows, err := tr.db.Query(ctx, `SELECT jti, expires_at FROM revoked_tokens WHERE expires_at > NOW()`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			jti       string
+			expiresAt time.Time
+		)
+		if err := rows.Scan(&jti, &expiresAt); err != nil {
+			return err
+		}
+		tokens[jti] = expiresAt
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	users := make(map[int]time.Time)
+	userRows, err := tr.db.Query(ctx, `SELECT users_id, revoked_at FROM user_session_revocations`)
+	if err != nil {
+		return err
+	}
+	defer userRows.Close()
+	for userRows.Next() {
+		var (
+			userID    int
+			revokedAt time.Time
+		)
+		if err := userRows.Scan(&userID, &revokedAt); err != nil {
+			return err
+		}
+		users[userID] = revokedAt
+	}
+	if err := userRows.Err(); err != nil {
+		return err
+	}
+
+	tr.revocations.mu.Lock()
+	tr.revocations.tokens = tokens
+	tr.revocations.users = users
+	tr.revocations.loadedAt = time.Now()
+	tr.revocations.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrUserNotFound = errors.New("user not found")
+
+type UserRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewUserRepo(db *pgxpool.Pool) *UserRepo {
+	return &UserRepo{db: db}
+}
+
+func (ur *UserRepo) GetUsers(ctx context.Context, page, pageSize int, search, role string) ([]models.User, error) {
+	offset := (page - 1) * pageSize
+
+	sql := `
+		SELECT u.id, u.email, u.role, u.created_at, u.updated_at, u.disabled_at,
+		       p.firstname, p.lastname, p.phone_number
+		FROM users u
+		LEFT JOIN profile p ON p.user_id = u.id
+		WHERE LOWER(u.email) LIKE LOWER($1)
+		AND ($2::text = '' OR u.role::text = $2::text)
+		ORDER BY u.id ASC
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := ur.db.Query(ctx, sql, "%"+search+"%", role, pageSize, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		var u models.User
+		if err := rows.Scan(
+			&u.ID, &u.Email, &u.Role, &u.CreatedAt, &u.UpdatedAt, &u.DisabledAt,
+			&u.Profile.FirstName, &u.Profile.LastName, &u.Profile.PhoneNumber,
+		); err != nil {
+			return nil, err
+		}
+		u.Profile.UserID = u.ID
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+func (ur *UserRepo) UserExists(ctx context.Context, id int) (bool, error) {
+	var exists bool
+	err := ur.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`, id).Scan(&exists)
+	return exists, err
+}
+
+func (ur *UserRepo) UpdateRole(ctx context.Context, id int, role models.Role) error {
+	tag, err := ur.db.Exec(ctx, `UPDATE users SET role = $1, updated_at = NOW() WHERE id = $2`, role, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func (ur *UserRepo) SetDisabled(ctx context.Context, id int, disabled bool) error {
+	sql := `
+		UPDATE users
+		SET disabled_at = CASE WHEN $1 THEN COALESCE(disabled_at, NOW()) ELSE NULL END,
+		    updated_at = NOW()
+		WHERE id = $2
+	`
+	tag, err := ur.db.Exec(ctx, sql, disabled, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package routers
+
+import (
+	"github.com/Darari17/be-go-tickitz-app/internal/handlers"
+	"github.com/Darari17/be-go-tickitz-app/internal/middlewares"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func initAnalyticsRouter(router *gin.Engine, db *pgxpool.Pool, tokenRepo *repositories.TokenRepo) {
+	adminAnalyticsGroup := router.Group("/admin/analytics", middlewares.VerifyToken(tokenRepo), middlewares.Access("admin"))
+
+	analyticsRepo := repositories.NewAnalyticsRepo(db)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsRepo)
+
+	adminAnalyticsGroup.GET("/sales", analyticsHandler.GetSales)
+}
@@ -2,17 +2,20 @@ package routers
 
 import (
 	"github.com/Darari17/be-go-tickitz-app/internal/handlers"
+	"github.com/Darari17/be-go-tickitz-app/internal/middlewares"
 	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func initAuthRouter(router *gin.Engine, db *pgxpool.Pool) {
+func initAuthRouter(router *gin.Engine, db *pgxpool.Pool, tokenRepo *repositories.TokenRepo) {
 	authGroup := router.Group("/auth")
 
 	authRepo := repositories.NewAuthRepo(db)
-	authHandler := handlers.NewAuthHandler(authRepo)
+	authHandler := handlers.NewAuthHandler(authRepo, tokenRepo)
 
 	authGroup.POST("/login", authHandler.Login)
+	authGroup.POST("/refresh", authHandler.Refresh)
 	authGroup.POST("/register", authHandler.Register)
+	authGroup.POST("/logout", middlewares.VerifyToken(tokenRepo), authHandler.Logout)
 }
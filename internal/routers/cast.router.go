@@ -0,0 +1,21 @@
+package routers
+
+import (
+	"github.com/Darari17/be-go-tickitz-app/internal/handlers"
+	"github.com/Darari17/be-go-tickitz-app/internal/middlewares"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func initCastRouter(router *gin.Engine, db *pgxpool.Pool, tokenRepo *repositories.TokenRepo) {
+	castRepo := repositories.NewCastRepo(db)
+	castHandler := handlers.NewCastHandler(castRepo)
+
+	router.GET("/casts", castHandler.GetCasts)
+
+	adminCastRouter := router.Group("/admin/casts", middlewares.VerifyToken(tokenRepo), middlewares.Access("admin"))
+	adminCastRouter.POST("", castHandler.CreateCast)
+	adminCastRouter.PUT("/:id", castHandler.UpdateCast)
+	adminCastRouter.DELETE("/:id", castHandler.DeleteCast)
+}
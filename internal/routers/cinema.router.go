@@ -0,0 +1,33 @@
+package routers
+
+import (
+	"github.com/Darari17/be-go-tickitz-app/internal/handlers"
+	"github.com/Darari17/be-go-tickitz-app/internal/middlewares"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func initCinemaRouter(router *gin.Engine, db *pgxpool.Pool, tokenRepo *repositories.TokenRepo) {
+	cinemaRepo := repositories.NewCinemaRepo(db)
+	cinemaHandler := handlers.NewCinemaHandler(cinemaRepo)
+
+	router.GET("/cinemas", cinemaHandler.GetCinemas)
+	router.GET("/locations", cinemaHandler.GetLocations)
+	router.GET("/times", cinemaHandler.GetTimes)
+
+	adminRouter := router.Group("/admin", middlewares.VerifyToken(tokenRepo), middlewares.Access("admin"))
+	adminRouter.POST("/cinemas", cinemaHandler.CreateCinema)
+	adminRouter.PUT("/cinemas/:id", cinemaHandler.UpdateCinema)
+	adminRouter.DELETE("/cinemas/:id", cinemaHandler.DeleteCinema)
+	adminRouter.GET("/cinemas/:id/layout", cinemaHandler.GetSeatLayout)
+	adminRouter.PUT("/cinemas/:id/layout", cinemaHandler.UpdateSeatLayout)
+
+	adminRouter.POST("/locations", cinemaHandler.CreateLocation)
+	adminRouter.PUT("/locations/:id", cinemaHandler.UpdateLocation)
+	adminRouter.DELETE("/locations/:id", cinemaHandler.DeleteLocation)
+
+	adminRouter.POST("/times", cinemaHandler.CreateTime)
+	adminRouter.PUT("/times/:id", cinemaHandler.UpdateTime)
+	adminRouter.DELETE("/times/:id", cinemaHandler.DeleteTime)
+}
@@ -0,0 +1,21 @@
+package routers
+
+import (
+	"github.com/Darari17/be-go-tickitz-app/internal/handlers"
+	"github.com/Darari17/be-go-tickitz-app/internal/middlewares"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func initExportRouter(router *gin.Engine, db *pgxpool.Pool, tokenRepo *repositories.TokenRepo) {
+	adminExportGroup := router.Group("/admin/exports", middlewares.VerifyToken(tokenRepo), middlewares.Access("admin"))
+
+	orderRepo := repositories.NewOrderRepo(db)
+	analyticsRepo := repositories.NewAnalyticsRepo(db)
+	exportHandler := handlers.NewExportHandler(orderRepo, analyticsRepo)
+
+	adminExportGroup.GET("/orders", exportHandler.ExportOrders)
+	adminExportGroup.GET("/schedules/:schedule_id/manifest", exportHandler.ExportSeatManifest)
+	adminExportGroup.GET("/sales", exportHandler.ExportSales)
+}
@@ -0,0 +1,21 @@
+package routers
+
+import (
+	"github.com/Darari17/be-go-tickitz-app/internal/handlers"
+	"github.com/Darari17/be-go-tickitz-app/internal/middlewares"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func initGenreRouter(router *gin.Engine, db *pgxpool.Pool, tokenRepo *repositories.TokenRepo) {
+	genreRepo := repositories.NewGenreRepo(db)
+	genreHandler := handlers.NewGenreHandler(genreRepo)
+
+	router.GET("/genres", genreHandler.GetGenres)
+
+	adminGenreRouter := router.Group("/admin/genres", middlewares.VerifyToken(tokenRepo), middlewares.Access("admin"))
+	adminGenreRouter.POST("", genreHandler.CreateGenre)
+	adminGenreRouter.PUT("/:id", genreHandler.UpdateGenre)
+	adminGenreRouter.DELETE("/:id", genreHandler.DeleteGenre)
+}
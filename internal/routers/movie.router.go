@@ -4,13 +4,14 @@ import (
 	"github.com/Darari17/be-go-tickitz-app/internal/handlers"
 	"github.com/Darari17/be-go-tickitz-app/internal/middlewares"
 	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/Darari17/be-go-tickitz-app/internal/storage"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func initMovieRouter(router *gin.Engine, db *pgxpool.Pool) {
+func initMovieRouter(router *gin.Engine, db *pgxpool.Pool, tokenRepo *repositories.TokenRepo, store storage.Storage) {
 	movieRepo := repositories.NewMovieRepo(db)
-	movieHandler := handlers.NewMovieHandler(movieRepo)
+	movieHandler := handlers.NewMovieHandler(movieRepo, store)
 
 	movieRouter := router.Group("/movies")
 	movieRouter.GET("/upcoming", movieHandler.GetUpcomingMovies)
@@ -19,9 +20,14 @@ func initMovieRouter(router *gin.Engine, db *pgxpool.Pool) {
 	movieRouter.GET("/:id", movieHandler.GetMovieDetail)
 	movieRouter.GET("/:id/schedules", movieHandler.GetSchedule)
 	movieRouter.GET("/schedules/:schedule_id/seats", movieHandler.GetAvailableSeats)
+	movieRouter.GET("/schedules/:schedule_id/seat-map", movieHandler.GetSeatMap)
 
-	adminMovieRouter := router.Group("/admin/movies", middlewares.VerifyToken, middlewares.Access("admin"))
+	adminMovieRouter := router.Group("/admin/movies", middlewares.VerifyToken(tokenRepo), middlewares.Access("admin"))
 	adminMovieRouter.GET("", movieHandler.GetAllMovies)
+	adminMovieRouter.POST("", movieHandler.CreateMovie)
+	adminMovieRouter.POST("/import", movieHandler.ImportMovies)
 	adminMovieRouter.PUT("/:id", movieHandler.UpdateMovie)
+	adminMovieRouter.PUT("/:id/poster", movieHandler.UploadMoviePoster)
+	adminMovieRouter.PUT("/:id/backdrop", movieHandler.UploadMovieBackdrop)
 	adminMovieRouter.DELETE("/:id", movieHandler.DeleteMovie)
 }
@@ -8,13 +8,26 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func initOrderRouter(router *gin.Engine, db *pgxpool.Pool) {
-	orderGroup := router.Group("/orders", middlewares.VerifyToken)
+func initOrderRouter(router *gin.Engine, db *pgxpool.Pool, tokenRepo *repositories.TokenRepo) {
+	orderGroup := router.Group("/orders", middlewares.VerifyToken(tokenRepo))
 
 	orderRepo := repositories.NewOrderRepo(db)
 	orderHandler := handlers.NewOrderHandler(orderRepo)
 
+	holdRepo := repositories.NewHoldRepo(db)
+	holdHandler := handlers.NewHoldHandler(holdRepo)
+
+	orderGroup.POST("/holds", holdHandler.CreateHold)
+	orderGroup.DELETE("/holds/:id", holdHandler.ReleaseHold)
+	orderGroup.POST("/quote", orderHandler.QuoteOrder)
 	orderGroup.POST("", orderHandler.CreateOrder)
+	orderGroup.GET("/me", orderHandler.GetMyOrders)
 	orderGroup.GET("/:id", orderHandler.GetOrderByID)
+	orderGroup.GET("/:id/ticket.png", orderHandler.GetTicket)
 	orderGroup.GET("/user/:user_id", orderHandler.GetOrdersByUser)
+
+	adminOrderGroup := router.Group("/admin/orders", middlewares.VerifyToken(tokenRepo), middlewares.Access("admin"))
+	adminOrderGroup.GET("", orderHandler.GetOrdersForAdmin)
+	adminOrderGroup.GET("/:id", orderHandler.GetOrderDetailForAdmin)
+	adminOrderGroup.PATCH("/:id/status", orderHandler.UpdateOrderStatus)
 }
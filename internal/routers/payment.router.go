@@ -0,0 +1,33 @@
+package routers
+
+import (
+	"github.com/Darari17/be-go-tickitz-app/internal/handlers"
+	"github.com/Darari17/be-go-tickitz-app/internal/middlewares"
+	"github.com/Darari17/be-go-tickitz-app/internal/payments"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func initPaymentRouter(router *gin.Engine, db *pgxpool.Pool, tokenRepo *repositories.TokenRepo, provider payments.PaymentProvider) {
+	paymentRepo := repositories.NewPaymentRepo(db)
+	orderRepo := repositories.NewOrderRepo(db)
+	paymentHandler := handlers.NewPaymentHandler(paymentRepo, orderRepo, provider)
+
+	router.POST("/orders/:id/pay", middlewares.VerifyToken(tokenRepo), paymentHandler.PayOrder)
+	router.POST("/orders/:id/cancel", middlewares.VerifyToken(tokenRepo), paymentHandler.CancelOrder)
+	router.POST("/admin/orders/:id/cancel", middlewares.VerifyToken(tokenRepo), middlewares.Access("admin"), paymentHandler.ForceCancelOrder)
+
+	paymentRouter := router.Group("/payments")
+	paymentRouter.GET("/methods", paymentHandler.GetPaymentMethods)
+	paymentRouter.POST("/webhook", paymentHandler.PaymentWebhook)
+	if _, ok := provider.(*payments.FakeProvider); ok {
+		paymentRouter.POST("/fake/:charge_id", middlewares.VerifyToken(tokenRepo), paymentHandler.FakeCheckout)
+	}
+
+	adminPaymentRouter := router.Group("/admin/payments", middlewares.VerifyToken(tokenRepo), middlewares.Access("admin"))
+	adminPaymentRouter.GET("/methods", paymentHandler.GetAllPaymentMethods)
+	adminPaymentRouter.POST("/methods", paymentHandler.CreatePaymentMethod)
+	adminPaymentRouter.PUT("/methods/:id", paymentHandler.UpdatePaymentMethod)
+	adminPaymentRouter.DELETE("/methods/:id", paymentHandler.DeletePaymentMethod)
+}
@@ -4,16 +4,18 @@ import (
 	"github.com/Darari17/be-go-tickitz-app/internal/handlers"
 	"github.com/Darari17/be-go-tickitz-app/internal/middlewares"
 	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/Darari17/be-go-tickitz-app/internal/storage"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func initProfileRouter(router *gin.Engine, db *pgxpool.Pool) {
-	profileGroup := router.Group("/profile", middlewares.VerifyToken, middlewares.Access("user"))
+func initProfileRouter(router *gin.Engine, db *pgxpool.Pool, tokenRepo *repositories.TokenRepo, store storage.Storage) {
+	profileGroup := router.Group("/profile", middlewares.VerifyToken(tokenRepo), middlewares.Access("user"))
 
 	profileRepo := repositories.NewProfileRepo(db)
-	profileHandler := handlers.NewProfileHandler(profileRepo)
+	profileHandler := handlers.NewProfileHandler(profileRepo, store)
 
 	profileGroup.GET("", profileHandler.GetProfile)
 	profileGroup.PUT("", profileHandler.UpdateProfile)
+	profileGroup.PUT("/avatar", profileHandler.UpdateAvatar)
 }
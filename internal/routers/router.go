@@ -1,8 +1,13 @@
 package routers
 
 import (
+	"errors"
+	"log"
 	"net/http"
 
+	"github.com/Darari17/be-go-tickitz-app/internal/payments"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/Darari17/be-go-tickitz-app/internal/storage"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -14,10 +19,33 @@ import (
 func InitRouter(db *pgxpool.Pool) *gin.Engine {
 	router := gin.Default()
 
-	initAuthRouter(router, db)
-	initMovieRouter(router, db)
-	initOrderRouter(router, db)
-	initProfileRouter(router, db)
+	// satu TokenRepo dipakai bersama supaya cache pencabutan token konsisten
+	tokenRepo := repositories.NewTokenRepo(db)
+
+	// pembayaran online opsional, tanpa provider endpoint pay dan webhook membalas 503
+	paymentProvider, err := payments.NewProvider()
+	if errors.Is(err, payments.ErrProviderNotConfigured) {
+		log.Println("Warning: online payment disabled\nCause: ", err.Error())
+	} else if err != nil {
+		log.Fatalln("Failed to init payment provider\nCause: ", err.Error())
+	}
+
+	// file upload disajikan lewat router.Static("/img", "public") di bawah
+	store := storage.NewLocalStorage("public", "/img")
+
+	initAuthRouter(router, db, tokenRepo)
+	initMovieRouter(router, db, tokenRepo, store)
+	initGenreRouter(router, db, tokenRepo)
+	initCastRouter(router, db, tokenRepo)
+	initCinemaRouter(router, db, tokenRepo)
+	initScheduleRouter(router, db, tokenRepo)
+	initOrderRouter(router, db, tokenRepo)
+	initPaymentRouter(router, db, tokenRepo, paymentProvider)
+	initProfileRouter(router, db, tokenRepo, store)
+	initUserRouter(router, db, tokenRepo)
+	initStaffRouter(router, db, tokenRepo)
+	initAnalyticsRouter(router, db, tokenRepo)
+	initExportRouter(router, db, tokenRepo)
 
 	router.Static("/img", "public")
 
@@ -0,0 +1,20 @@
+package routers
+
+import (
+	"github.com/Darari17/be-go-tickitz-app/internal/handlers"
+	"github.com/Darari17/be-go-tickitz-app/internal/middlewares"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func initScheduleRouter(router *gin.Engine, db *pgxpool.Pool, tokenRepo *repositories.TokenRepo) {
+	adminScheduleRouter := router.Group("/admin/schedules", middlewares.VerifyToken(tokenRepo), middlewares.Access("admin"))
+
+	scheduleRepo := repositories.NewScheduleRepo(db)
+	scheduleHandler := handlers.NewScheduleHandler(scheduleRepo)
+
+	adminScheduleRouter.POST("", scheduleHandler.CreateSchedule)
+	adminScheduleRouter.PUT("/:id", scheduleHandler.UpdateSchedule)
+	adminScheduleRouter.DELETE("/:id", scheduleHandler.DeleteSchedule)
+}
@@ -0,0 +1,17 @@
+package routers
+
+import (
+	"github.com/Darari17/be-go-tickitz-app/internal/handlers"
+	"github.com/Darari17/be-go-tickitz-app/internal/middlewares"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func initStaffRouter(router *gin.Engine, db *pgxpool.Pool, tokenRepo *repositories.TokenRepo) {
+	orderRepo := repositories.NewOrderRepo(db)
+	staffHandler := handlers.NewStaffHandler(orderRepo)
+
+	staffRouter := router.Group("/staff", middlewares.VerifyToken(tokenRepo), middlewares.Access("staff", "admin"))
+	staffRouter.POST("/checkin", staffHandler.CheckIn)
+}
@@ -0,0 +1,21 @@
+package routers
+
+import (
+	"github.com/Darari17/be-go-tickitz-app/internal/handlers"
+	"github.com/Darari17/be-go-tickitz-app/internal/middlewares"
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func initUserRouter(router *gin.Engine, db *pgxpool.Pool, tokenRepo *repositories.TokenRepo) {
+	adminUserGroup := router.Group("/admin/users", middlewares.VerifyToken(tokenRepo), middlewares.Access("admin"))
+
+	userRepo := repositories.NewUserRepo(db)
+	userHandler := handlers.NewUserHandler(userRepo, tokenRepo)
+
+	adminUserGroup.GET("", userHandler.GetUsers)
+	adminUserGroup.PATCH("/:id/role", userHandler.UpdateUserRole)
+	adminUserGroup.PATCH("/:id/status", userHandler.UpdateUserStatus)
+	adminUserGroup.DELETE("/:id/sessions", userHandler.RevokeUserSessions)
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var ErrInvalidPath = errors.New("invalid storage path")
+
+// Storage menyimpan file upload dan mengembalikan path publik yang bisa diakses client.
+// Implementasi lain (misalnya object storage) cukup memenuhi interface ini.
+type Storage interface {
+	Save(ctx context.Context, name string, r io.Reader) (string, error)
+	Delete(ctx context.Context, publicPath string) error
+}
+
+// LocalStorage menyimpan file di disk di bawah root, yang disajikan router di baseURL
+// (router.Static("/img", "public") berarti root "public" dan baseURL "/img")
+type LocalStorage struct {
+	root    string
+	baseURL string
+}
+
+func NewLocalStorage(root, baseURL string) *LocalStorage {
+	return &LocalStorage{root: root, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Save menulis file ke root/name lewat file sementara lalu rename, jadi file yang setengah tertulis
+// tidak pernah tersaji. Nama yang sama ditimpa, aman karena nama file berasal dari hash isinya.
+func (ls *LocalStorage) Save(ctx context.Context, name string, r io.Reader) (string, error) {
+	target, err := ls.diskPath(name)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return "", err
+	}
+	return ls.baseURL + "/" + path.Clean(name), nil
+}
+
+// Delete menghapus file berdasarkan path publiknya. Path di luar baseURL (misalnya URL TMDB)
+// dan file yang sudah tidak ada diabaikan.
+func (ls *LocalStorage) Delete(ctx context.Context, publicPath string) error {
+	name, ok := strings.CutPrefix(publicPath, ls.baseURL+"/")
+	if !ok {
+		return nil
+	}
+	target, err := ls.diskPath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(target); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// diskPath menolak nama yang keluar dari root (misalnya "../")
+func (ls *LocalStorage) diskPath(name string) (string, error) {
+	if name == "" || !filepath.IsLocal(filepath.FromSlash(name)) {
+		return "", ErrInvalidPath
+	}
+	return filepath.Join(ls.root, filepath.FromSlash(name)), nil
+}
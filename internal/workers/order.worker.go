@@ -0,0 +1,31 @@
+package workers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Darari17/be-go-tickitz-app/internal/repositories"
+)
+
+// ExpireOrders secara berkala mengubah order pending yang lewat batas bayar menjadi expired.
+// Kursinya sudah dianggap kosong sejak expires_at lewat, worker ini hanya merapikan status dan expired_at.
+func ExpireOrders(ctx context.Context, orderRepo *repositories.OrderRepo, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		expired, err := orderRepo.ExpirePendingOrders(ctx)
+		if err != nil {
+			log.Println("Failed to expire pending orders\nCause: ", err.Error())
+		} else if expired > 0 {
+			log.Printf("%d pending orders expired\n", expired)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"crypto/rand"
 	"errors"
 	"os"
 	"time"
@@ -14,12 +15,34 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
+// AccessTokenTTL membaca masa berlaku access token dari env JWT_ACCESS_TTL (contoh: "30m"), default 30 menit
+func AccessTokenTTL() time.Duration {
+	return durationFromEnv("JWT_ACCESS_TTL", time.Minute*30)
+}
+
+// RefreshTokenTTL membaca masa berlaku refresh token dari env JWT_REFRESH_TTL (contoh: "168h"), default 7 hari
+func RefreshTokenTTL() time.Duration {
+	return durationFromEnv("JWT_REFRESH_TTL", time.Hour*24*7)
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	d, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || d <= 0 {
+		return fallback
+	}
+	return d
+}
+
 func NewJWTClaims(userid int, role string) *Claims {
+	now := time.Now()
 	return &Claims{
 		UserId: userid,
 		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * 30)),
+			// jti dipakai untuk mencabut token tertentu (logout)
+			ID:        rand.Text(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL())),
 			Issuer:    os.Getenv("JWT_ISSUER"),
 		},
 	}
@@ -0,0 +1,82 @@
+package pkg
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidTicket       = errors.New("invalid ticket")
+	ErrTicketExpired       = errors.New("ticket expired")
+	ErrTicketSecretMissing = errors.New("TICKET_SECRET or JWT_SECRET must be set")
+)
+
+// TicketClaims adalah isi QR tiket. Token berbentuk base64url(payload).base64url(hmac-sha256),
+// jadi usher bisa memverifikasi tiket tanpa menebak-nebak ID order.
+type TicketClaims struct {
+	OrderID    int      `json:"oid"`
+	ScheduleID int      `json:"sid"`
+	Seats      []string `json:"seats"`
+	ExpiresAt  int64    `json:"exp"`
+}
+
+// ticketSecret dibaca dari env TICKET_SECRET, fallback ke JWT_SECRET.
+// Tanpa secret tiket bisa dipalsukan siapa saja, jadi key kosong selalu ditolak.
+func ticketSecret() ([]byte, error) {
+	if secret := os.Getenv("TICKET_SECRET"); secret != "" {
+		return []byte(secret), nil
+	}
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		return []byte(secret), nil
+	}
+	return nil, ErrTicketSecretMissing
+}
+
+func signTicketPayload(secret []byte, payload string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func SignTicket(claims TicketClaims) (string, error) {
+	secret, err := ticketSecret()
+	if err != nil {
+		return "", err
+	}
+	raw, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	return payload + "." + signTicketPayload(secret, payload), nil
+}
+
+func VerifyTicket(token string) (*TicketClaims, error) {
+	secret, err := ticketSecret()
+	if err != nil {
+		return nil, err
+	}
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signTicketPayload(secret, payload)), []byte(signature)) {
+		return nil, ErrInvalidTicket
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidTicket
+	}
+	var claims TicketClaims
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return nil, ErrInvalidTicket
+	}
+	if time.Now().Unix() > claims.ExpiresAt {
+		return &claims, ErrTicketExpired
+	}
+	return &claims, nil
+}
@@ -0,0 +1,128 @@
+package pkg
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func signTestTicket(t *testing.T, claims TicketClaims) string {
+	t.Helper()
+	token, err := SignTicket(claims)
+	if err != nil {
+		t.Fatalf("SignTicket: %v", err)
+	}
+	return token
+}
+
+func TestVerifyTicket(t *testing.T) {
+	t.Setenv("TICKET_SECRET", "ticket-secret")
+	t.Setenv("JWT_SECRET", "")
+
+	valid := TicketClaims{OrderID: 7, ScheduleID: 3, Seats: []string{"A1", "A2"}, ExpiresAt: time.Now().Add(time.Hour).Unix()}
+	validToken := signTestTicket(t, valid)
+	expiredToken := signTestTicket(t, TicketClaims{OrderID: 7, ExpiresAt: time.Now().Add(-time.Minute).Unix()})
+
+	payload, signature, _ := strings.Cut(validToken, ".")
+	forged := valid
+	forged.OrderID = 8
+	forgedToken := signTestTicket(t, forged)
+	forgedPayload, _, _ := strings.Cut(forgedToken, ".")
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "tiket valid", token: validToken},
+		{name: "tiket expired", token: expiredToken, wantErr: ErrTicketExpired},
+		{name: "token kosong", token: "", wantErr: ErrInvalidTicket},
+		{name: "tanpa signature", token: payload, wantErr: ErrInvalidTicket},
+		{name: "payload ditukar", token: forgedPayload + "." + signature, wantErr: ErrInvalidTicket},
+		{name: "signature diubah", token: payload + "." + strings.ToUpper(signature), wantErr: ErrInvalidTicket},
+		{
+			name:    "payload bukan json",
+			token:   base64.RawURLEncoding.EncodeToString([]byte("x")) + "." + signature,
+			wantErr: ErrInvalidTicket,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := VerifyTicket(tt.token)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if claims.OrderID != valid.OrderID || claims.ScheduleID != valid.ScheduleID || strings.Join(claims.Seats, ",") != "A1,A2" {
+				t.Fatalf("claims = %+v, want %+v", *claims, valid)
+			}
+		})
+	}
+}
+
+func TestExpiredTicketKeepsClaims(t *testing.T) {
+	t.Setenv("TICKET_SECRET", "ticket-secret")
+
+	token := signTestTicket(t, TicketClaims{OrderID: 9, ExpiresAt: time.Now().Add(-time.Minute).Unix()})
+	claims, err := VerifyTicket(token)
+	if !errors.Is(err, ErrTicketExpired) {
+		t.Fatalf("err = %v, want ErrTicketExpired", err)
+	}
+	if claims == nil || claims.OrderID != 9 {
+		t.Fatalf("claims = %+v, want order 9", claims)
+	}
+}
+
+func TestTicketSecret(t *testing.T) {
+	claims := TicketClaims{OrderID: 1, ExpiresAt: time.Now().Add(time.Hour).Unix()}
+
+	tests := []struct {
+		name         string
+		signTicket   string
+		signJWT      string
+		verifyTicket string
+		verifyJWT    string
+		wantSignErr  error
+		wantErr      error
+	}{
+		{name: "secret sama", signTicket: "a", verifyTicket: "a"},
+		{name: "fallback ke JWT_SECRET", signJWT: "jwt", verifyJWT: "jwt"},
+		{name: "TICKET_SECRET didahulukan", signTicket: "a", signJWT: "jwt", verifyTicket: "a", verifyJWT: "other"},
+		{name: "secret berbeda", signTicket: "a", verifyTicket: "b", wantErr: ErrInvalidTicket},
+		{name: "secret kosong saat verify", signTicket: "a", wantErr: ErrTicketSecretMissing},
+		{name: "secret kosong saat sign", wantSignErr: ErrTicketSecretMissing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TICKET_SECRET", tt.signTicket)
+			t.Setenv("JWT_SECRET", tt.signJWT)
+			token, err := SignTicket(claims)
+			if tt.wantSignErr != nil {
+				if !errors.Is(err, tt.wantSignErr) {
+					t.Fatalf("sign err = %v, want %v", err, tt.wantSignErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SignTicket: %v", err)
+			}
+
+			t.Setenv("TICKET_SECRET", tt.verifyTicket)
+			t.Setenv("JWT_SECRET", tt.verifyJWT)
+			_, err = VerifyTicket(token)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}